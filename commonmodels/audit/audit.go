@@ -1,24 +1,27 @@
 package audit
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
 
-	err "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/enum/errors"
-	val_err "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validation_error"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	err "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	val_err "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
 )
 
 type AuditEntry struct {
 	// Identity
-	TenantID uuid.UUID `json:"tenant_id"` // From JWT or request context
-	Subject  string    `json:"subject"`   // Identity from JWT 'sub'
-	Jti      uuid.UUID `json:"jti"`       // Unique JWT identifier
+	TenantID ids.TenantID `json:"tenant_id"` // From JWT or request context
+	Subject  string       `json:"subject"`   // Identity from JWT 'sub'
+	Jti      uuid.UUID    `json:"jti"`       // Unique JWT identifier
 
 	// Operation
-	HTTPMethod string    `json:"http_method"` // "POST", "PATCH", etc.
-	Resource   string    `json:"resource"`    // E.g. "target", "result"
-	ResourceID uuid.UUID `json:"resource_id"` // Optional
+	HTTPMethod string       `json:"http_method"` // "POST", "PATCH", etc.
+	Resource   string       `json:"resource"`    // E.g. "target", "result"
+	ResourceID ids.EntityID `json:"resource_id"` // Optional
 
 	// Data (diff/state)
 	Payload any `json:"payload"` // Partial or full state (optional)
@@ -36,6 +39,14 @@ type AuditEntry struct {
 	Correlation string    `json:"correlation_id"` // Optional: cross-service trace
 }
 
+// Stamp sets a zero Timestamp to the time of the clock in ctx (see
+// clock.WithClock).
+func (a *AuditEntry) Stamp(ctx context.Context) {
+	if a.Timestamp.IsZero() {
+		a.Timestamp = clock.Now(ctx)
+	}
+}
+
 func (a *AuditEntry) Validate(locale string) []val_err.ValidationError {
 	var errors []val_err.ValidationError
 	if a.Subject == "" {
@@ -47,7 +58,7 @@ func (a *AuditEntry) Validate(locale string) []val_err.ValidationError {
 		})
 	}
 
-	if a.TenantID == uuid.Nil {
+	if a.TenantID.IsZero() {
 		errors = append(errors, val_err.ValidationError{
 			Field:   "tenant_id",
 			Message: err.HumanMessageLocale(locale, err.Required, "tenant_id"),
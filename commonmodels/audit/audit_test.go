@@ -1,6 +1,7 @@
 package audit_test
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 	"time"
@@ -8,12 +9,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
-	models "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/audit"
+	models "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/audit"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
 )
 
 func TestAuditEntry_JSON_MarshalBasic(t *testing.T) {
-	tenantID := uuid.New()
-	resourceID := uuid.New()
+	tenantID := ids.New[ids.Tenant]()
+	resourceID := ids.New[ids.Entity]()
 
 	entry := models.AuditEntry{
 		TenantID:   tenantID,
@@ -47,7 +50,7 @@ func TestAuditEntry_JSON_MarshalBasic(t *testing.T) {
 
 func TestAuditEntry_JSON_Keys(t *testing.T) {
 	entry := models.AuditEntry{
-		TenantID:   uuid.New(),
+		TenantID:   ids.New[ids.Tenant](),
 		Subject:    "user@example.com",
 		HTTPMethod: "DELETE",
 		Resource:   "result",
@@ -72,7 +75,7 @@ func TestAuditEntry_JSON_Payload(t *testing.T) {
 	}
 
 	entry := models.AuditEntry{
-		TenantID:   uuid.New(),
+		TenantID:   ids.New[ids.Tenant](),
 		Subject:    "user@example.com",
 		HTTPMethod: "PATCH",
 		Resource:   "target",
@@ -87,3 +90,10 @@ func TestAuditEntry_JSON_Payload(t *testing.T) {
 	jsonStr := string(data)
 	assert.Contains(t, jsonStr, `"timeout_ms":1000`)
 }
+
+func TestAuditEntry_Stamp(t *testing.T) {
+	fixed := time.Date(2025, 7, 9, 14, 30, 0, 0, time.UTC)
+	var entry models.AuditEntry
+	entry.Stamp(clock.WithClock(context.Background(), clock.NewFake(fixed)))
+	assert.Equal(t, fixed, entry.Timestamp)
+}
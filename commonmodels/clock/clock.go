@@ -0,0 +1,87 @@
+// Package clock abstracts the current time so timestamps can be controlled
+// per request or per test instead of through a process-wide variable.
+//
+// Pass a clock through the context; models stamping time read it from there
+// and fall back to the real clock:
+//
+//	fake := clock.NewFake(time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC))
+//	ctx := clock.WithClock(context.Background(), fake)
+//	db.WithContext(ctx).Create(&ds) // CreatedAt == fake.Now()
+//	fake.Advance(time.Hour)
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock returns the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is the wall clock, in UTC.
+type Real struct{}
+
+// Now returns time.Now in UTC.
+func (Real) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// Fake is a manually controlled clock, safe for concurrent use.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake returns a Fake set to t.
+func NewFake(t time.Time) *Fake {
+	return &Fake{now: t}
+}
+
+// Now returns the time the clock is set to.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set sets the clock to t.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+}
+
+// Advance moves the clock forward by d and returns the new time.
+func (f *Fake) Advance(d time.Duration) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	return f.now
+}
+
+type ctxKey struct{}
+
+// WithClock returns a copy of ctx carrying c.
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, ctxKey{}, c)
+}
+
+// FromContext returns the clock stored by WithClock.
+func FromContext(ctx context.Context) (Clock, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	c, ok := ctx.Value(ctxKey{}).(Clock)
+	return c, ok && c != nil
+}
+
+// Now returns the time of the clock in ctx, or of Real when there is none.
+func Now(ctx context.Context) time.Time {
+	if c, ok := FromContext(ctx); ok {
+		return c.Now()
+	}
+	return Real{}.Now()
+}
@@ -0,0 +1,53 @@
+package clock_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+)
+
+func TestReal(t *testing.T) {
+	now := clock.Real{}.Now()
+	assert.Equal(t, time.UTC, now.Location())
+	assert.WithinDuration(t, time.Now(), now, time.Second)
+}
+
+func TestFake(t *testing.T) {
+	fixed := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(fixed)
+	assert.Equal(t, fixed, fake.Now())
+	assert.Equal(t, fixed.Add(time.Minute), fake.Advance(time.Minute))
+	assert.Equal(t, fixed.Add(time.Minute), fake.Now())
+
+	fake.Set(fixed)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fake.Advance(time.Second)
+			_ = fake.Now()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, fixed.Add(10*time.Second), fake.Now())
+}
+
+func TestContext(t *testing.T) {
+	fixed := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	ctx := clock.WithClock(context.Background(), clock.NewFake(fixed))
+
+	c, ok := clock.FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, fixed, c.Now())
+	assert.Equal(t, fixed, clock.Now(ctx))
+
+	_, ok = clock.FromContext(context.Background())
+	assert.False(t, ok)
+	assert.WithinDuration(t, time.Now(), clock.Now(context.Background()), time.Second)
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"maps"
+	"reflect"
+
+	status "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+// ClonedFromKey is the metadata key recording the ID of the cloned entity.
+const ClonedFromKey = "cloned_from"
+
+// Clone returns a copy of src ready to be persisted as a new entity.
+//
+// The copy gets a fresh ID, Revision 1, status.Draft and audit fields set
+// for subject; soft delete fields are cleared. Metadata and Tags are deep
+// copied and Metadata[ClonedFromKey] holds the source ID. Other fields of the
+// embedding struct are copied shallowly, so domain types holding maps or
+// slices must copy those themselves. The copy keeps the clock of src (see
+// UseClock).
+//
+// A non-nil tenantID clones into that tenant and rewrites the tenant_id tag;
+// the nil ID keeps the source tenant:
+//
+//	cp := core.Clone(ds, subject, ids.TenantID{})
+//	db.Create(cp)
+func Clone[T Entity](src T, subject string, tenantID ids.TenantID) T {
+	v := reflect.New(reflect.TypeOf(src).Elem())
+	v.Elem().Set(reflect.ValueOf(src).Elem())
+	dst := v.Interface().(T)
+
+	from := src.Core()
+	c := dst.Core()
+	c.Metadata.Data = maps.Clone(from.Metadata.Data)
+	c.Tags.Data = maps.Clone(from.Tags.Data)
+	if c.Metadata.Data == nil {
+		c.Metadata.Data = map[string]string{}
+	}
+	c.Metadata.Data[ClonedFromKey] = from.ID.String()
+
+	if tenantID.IsZero() {
+		tenantID = from.TenantID
+	}
+	c.ID = ids.EntityID{}
+	c.Status = status.Draft
+	c.DeletedAt = DeletedAt{}
+	c.DeletedBy = ""
+	c.Revision = 0
+	c.statusChanges = nil
+	if c.Tags.Data != nil && tenantID != from.TenantID {
+		delete(c.Tags.Data, "tenant_id")
+	}
+	c.Create(subject, from.Issuer, tenantID)
+	return dst
+}
@@ -0,0 +1,54 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+func TestClone(t *testing.T) {
+	src := newDataset()
+	src.Status = status.Active
+	src.Revision = 7
+	src.Delete("admin@domain.com")
+
+	fixed := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	src.UseClock(clock.NewFake(fixed))
+
+	cp := core.Clone(src, "cloner@domain.com", ids.TenantID{})
+	assert.NotEqual(t, src.ID, cp.ID)
+	assert.False(t, cp.ID.IsZero())
+	assert.Equal(t, tenantA, cp.TenantID)
+	assert.Equal(t, status.Draft, cp.Status)
+	assert.Equal(t, int64(1), cp.Revision)
+	assert.False(t, cp.IsDeleted())
+	assert.Empty(t, cp.DeletedBy)
+	assert.Equal(t, "cloner@domain.com", cp.CreatedBy)
+	assert.Equal(t, "cloner@domain.com", cp.ModifiedBy)
+	assert.Equal(t, fixed, cp.CreatedAt)
+	assert.Equal(t, "grasp-labs", cp.Issuer)
+	assert.Equal(t, "a", cp.FieldX)
+	assert.Equal(t, src.ID.String(), cp.Metadata.Data[core.ClonedFromKey])
+	assert.Empty(t, cp.Validate())
+
+	// Maps are not shared with the source.
+	cp.Tags.Data["env"] = "prod"
+	assert.NotContains(t, src.Tags.Data, "env")
+	assert.NotContains(t, src.Metadata.Data, core.ClonedFromKey)
+}
+
+func TestClone_into_other_tenant(t *testing.T) {
+	src := newDataset()
+
+	cp := core.Clone(src, "cloner@domain.com", tenantB)
+	assert.Equal(t, tenantB, cp.TenantID)
+	assert.Equal(t, tenantB.String(), cp.Tags.Data["tenant_id"])
+	assert.Equal(t, tenantA.String(), src.Tags.Data["tenant_id"])
+	assert.Empty(t, cp.Validate())
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+type ctxKey int
+
+const (
+	tenantCtxKey ctxKey = iota
+	skipTenantCtxKey
+	subjectCtxKey
+	issuerCtxKey
+)
+
+// WithActor returns a copy of ctx carrying the authenticated subject, its
+// issuer and tenant. Typically called once by authentication middleware:
+//
+//	ctx = core.WithActor(ctx, claims.Subject, claims.Issuer, tenantID)
+//	db.WithContext(ctx).Create(&dataset) // CreatedBy, Issuer, TenantID filled by hooks
+func WithActor(ctx context.Context, subject, issuer string, tenantID ids.TenantID) context.Context {
+	return WithTenant(WithIssuer(WithSubject(ctx, subject), issuer), tenantID)
+}
+
+// WithSubject returns a copy of ctx carrying the authenticated subject.
+func WithSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, subjectCtxKey, subject)
+}
+
+// SubjectFromContext returns the subject stored by WithSubject.
+func SubjectFromContext(ctx context.Context) (string, bool) {
+	return stringFromContext(ctx, subjectCtxKey)
+}
+
+// WithIssuer returns a copy of ctx carrying the issuer of the subject.
+func WithIssuer(ctx context.Context, issuer string) context.Context {
+	return context.WithValue(ctx, issuerCtxKey, issuer)
+}
+
+// IssuerFromContext returns the issuer stored by WithIssuer.
+func IssuerFromContext(ctx context.Context) (string, bool) {
+	return stringFromContext(ctx, issuerCtxKey)
+}
+
+func stringFromContext(ctx context.Context, key ctxKey) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	s, ok := ctx.Value(key).(string)
+	return s, ok && s != ""
+}
+
+// WithTenant returns a copy of ctx carrying the tenant of the current request.
+//
+//	ctx = core.WithTenant(ctx, tenantID)
+//	db.WithContext(ctx).Find(&datasets) // tenant filtered by TenantPlugin
+func WithTenant(ctx context.Context, tenantID ids.TenantID) context.Context {
+	return context.WithValue(ctx, tenantCtxKey, tenantID)
+}
+
+// TenantFromContext returns the tenant stored by WithTenant.
+func TenantFromContext(ctx context.Context) (ids.TenantID, bool) {
+	if ctx == nil {
+		return ids.TenantID{}, false
+	}
+	id, ok := ctx.Value(tenantCtxKey).(ids.TenantID)
+	return id, ok && !id.IsZero()
+}
+
+// WithoutTenantScope returns a copy of ctx for which TenantPlugin is disabled.
+//
+// This is the explicit escape hatch for admin and maintenance jobs operating
+// across tenants; never derive it from user input.
+func WithoutTenantScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipTenantCtxKey, true)
+}
+
+// tenantScopeSkipped reports whether WithoutTenantScope was applied to ctx.
+func tenantScopeSkipped(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	skip, _ := ctx.Value(skipTenantCtxKey).(bool)
+	return skip
+}
@@ -0,0 +1,103 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+func TestContext_actor_roundtrip(t *testing.T) {
+	ctx := core.WithActor(context.Background(), "user@domain.com", "grasp-labs", tenantA)
+
+	subject, ok := core.SubjectFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "user@domain.com", subject)
+
+	issuer, ok := core.IssuerFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "grasp-labs", issuer)
+
+	tenantID, ok := core.TenantFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, tenantA, tenantID)
+
+	_, ok = core.SubjectFromContext(context.Background())
+	assert.False(t, ok)
+	_, ok = core.TenantFromContext(core.WithTenant(context.Background(), ids.TenantID{}))
+	assert.False(t, ok)
+}
+
+func TestBeforeCreate_fills_actor_from_context(t *testing.T) {
+	db := dryRunDB(t)
+	ctx := core.WithActor(context.Background(), "user@domain.com", "grasp-labs", tenantA)
+
+	d := &dataset{CoreModel: core.CoreModel{Name: "x", Status: status.Draft}}
+	assert.NoError(t, db.WithContext(ctx).Create(d).Error)
+	assert.Equal(t, "user@domain.com", d.CreatedBy)
+	assert.Equal(t, "user@domain.com", d.ModifiedBy)
+	assert.Equal(t, "grasp-labs", d.Issuer)
+	assert.Equal(t, tenantA, d.TenantID)
+	assert.Empty(t, d.Validate())
+
+	// Explicit values win over the context.
+	d = &dataset{CoreModel: core.CoreModel{Name: "x", CreatedBy: "other@domain.com", TenantID: tenantB}}
+	assert.NoError(t, db.WithContext(ctx).Create(d).Error)
+	assert.Equal(t, "other@domain.com", d.CreatedBy)
+	assert.Equal(t, tenantB, d.TenantID)
+}
+
+func TestBeforeUpdate_sets_modified_by_from_context(t *testing.T) {
+	db := dryRunDB(t)
+	ctx := core.WithSubject(context.Background(), "editor@domain.com")
+
+	d := &dataset{CoreModel: core.CoreModel{ID: ids.New[ids.Entity](), ModifiedBy: "creator@domain.com"}}
+	tx := db.WithContext(ctx).Model(d).Updates(map[string]any{"name": "y"})
+	assert.NoError(t, tx.Error)
+	assert.Equal(t, "editor@domain.com", d.ModifiedBy)
+	assert.Contains(t, tx.Statement.SQL.String(), "`modified_by`=?")
+	assert.Contains(t, tx.Statement.Vars, "editor@domain.com")
+}
+
+func TestSoftDelete_sets_deleted_by_from_context(t *testing.T) {
+	db := dryRunDB(t)
+	ctx := core.WithSubject(context.Background(), "editor@domain.com")
+
+	tx := db.WithContext(ctx).Delete(&dataset{CoreModel: core.CoreModel{ID: ids.New[ids.Entity]()}})
+	assert.NoError(t, tx.Error)
+	assert.Contains(t, tx.Statement.SQL.String(), "`deleted_by`=?")
+	assert.Contains(t, tx.Statement.Vars, "editor@domain.com")
+}
+
+func TestHooks_use_clock_from_context(t *testing.T) {
+	t.Parallel()
+	db := dryRunDB(t)
+	fixed := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(fixed)
+	ctx := clock.WithClock(core.WithSubject(context.Background(), "user@domain.com"), fake)
+
+	d := &dataset{CoreModel: core.CoreModel{Name: "x", Status: status.Draft}}
+	assert.NoError(t, db.WithContext(ctx).Create(d).Error)
+	assert.Equal(t, fixed, d.CreatedAt)
+	assert.Equal(t, fixed, d.ModifiedAt)
+
+	later := fake.Advance(time.Hour)
+	assert.NoError(t, db.WithContext(ctx).Model(d).Updates(map[string]any{"name": "y"}).Error)
+	assert.Equal(t, later, d.ModifiedAt)
+
+	// The context clock only applies to its statements, not to later
+	// in-memory changes.
+	later = fake.Advance(time.Hour)
+	d.Touch("user@domain.com")
+	assert.NotEqual(t, later, d.ModifiedAt)
+
+	tx := db.WithContext(ctx).Delete(&dataset{CoreModel: core.CoreModel{ID: ids.New[ids.Entity]()}})
+	assert.NoError(t, tx.Error)
+	assert.Contains(t, tx.Statement.Vars, later)
+}
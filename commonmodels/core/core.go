@@ -4,53 +4,96 @@
 package core
 
 import (
+	"context"
 	"time"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 
-	errC "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/enum/errors"
-	status "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/enum/status"
-	types "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/types"
-	verr "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validation_error"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	status "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	types "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/urn"
+	verr "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
 )
 
-// Now returns the current time in UTC.
+// Now returns the current time in UTC. It is used whenever no clock is
+// given through the context (clock.WithClock) or UseClock.
 //
-// Override in tests for deterministic timestamps, e.g.:
+// Deprecated: overriding Now affects every goroutine and races in parallel
+// tests. Pass a clock.Fake instead:
 //
-//	fixed := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
-//	old := Now
-//	Now = func() time.Time { return fixed }
-//	defer func() { Now = old }()
+//	ctx = clock.WithClock(ctx, clock.NewFake(fixed))
+//	ds.UseClock(clock.NewFake(fixed))
 var Now = func() time.Time { return time.Now().UTC() }
 
+// nowFrom returns the time of the clock in ctx, falling back to Now.
+func nowFrom(ctx context.Context) time.Time {
+	if c, ok := clock.FromContext(ctx); ok {
+		return c.Now().UTC()
+	}
+	return Now()
+}
+
 // BaseModel defines a common set of fields shared by persisted entities.
 // Embed this type in your structs to inherit IDs, tenancy, audit metadata,
 // status, and JSONB-backed free-form metadata and tags.
 //
 // GORM notes:
-//   - ID: uses Postgres pgcrypto's gen_random_uuid() by default.
+//   - ID: a UUIDv7 generated by the application (see ids.New) in Create or
+//     BeforeCreate; the column has no database default.
 //   - TenantID: indexed; Status+TenantID composite index for common filters.
 //   - CreatedAt/ModifiedAt: auto-populated by GORM; also set in hooks.
+//   - DeletedAt: soft delete marker; rows with a value are hidden from
+//     default queries (see DeletedAt, WithDeleted, OnlyDeleted).
+//   - Revision: starts at 1 and is bumped on every update; updates carrying a
+//     stale revision are rejected with *RevisionConflictError.
 type CoreModel struct {
-	ID          uuid.UUID                      `gorm:"type:uuid;primaryKey" json:"id"`
-	TenantID    uuid.UUID                      `gorm:"type:uuid" json:"tenant_id"`
+	ID          ids.EntityID                   `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID    ids.TenantID                   `gorm:"type:uuid;index;index:,composite:status_tenant,priority:2" json:"tenant_id"`
 	OwnerID     string                         `json:"owner_id"`
 	Issuer      string                         `json:"issuer"`
 	Name        string                         `json:"name"`
 	Version     string                         `json:"version"`
 	Description string                         `json:"description"`
-	Status      status.Status                  `json:"status"`
+	Status      status.Status                  `gorm:"index:,composite:status_tenant,priority:1" json:"status"`
 	Metadata    types.JSONB[map[string]string] `gorm:"column:metadata;type:jsonb" json:"metadata"`
 	Tags        types.JSONB[map[string]string] `gorm:"column:tags;type:jsonb"     json:"tags"`
 	CreatedAt   time.Time                      `json:"created_at"`
 	ModifiedAt  time.Time                      `json:"modified_at"`
 	CreatedBy   string                         `json:"created_by"`
 	ModifiedBy  string                         `json:"modified_by"`
+	DeletedAt   DeletedAt                      `gorm:"index" json:"deleted_at"`
+	DeletedBy   string                         `json:"deleted_by,omitempty"`
+	Revision    int64                          `gorm:"not null;default:1" json:"revision"`
+
+	// statusChanges holds changes not yet persisted (see StatusChanges).
+	statusChanges []StatusChange
+	// clk stamps times; Now when nil (see UseClock).
+	clk clock.Clock
+}
+
+// Entity is implemented by any pointer to a struct embedding CoreModel.
+// Package level helpers accept an Entity so they can operate on the embedded
+// core fields of arbitrary domain types.
+type Entity interface {
+	Core() *CoreModel
+}
+
+// Core returns the embedded CoreModel; it satisfies Entity.
+func (c *CoreModel) Core() *CoreModel {
+	return c
 }
 
-// Validate checks required fields, status values, and JSONB shape.
+// URN returns the entity's URN for the given resource type, e.g.
+// urn:grasp:<tenant>:dataset:<id>.
+func (c *CoreModel) URN(resource string) urn.URN {
+	return urn.New(c.TenantID, resource, c.ID.String())
+}
+
+// Validate checks required fields, status values, and JSONB shape. Metadata
+// and Tags are further checked against DefaultPolicy.
 //
 // Call this after defaults have been applied (e.g., after Create/Touch or
 // after GORM hooks). It returns nil if the model is valid.
@@ -65,10 +108,10 @@ func (b *CoreModel) ValidateWithContext(loc string, code string, locale string)
 		msg := errC.HumanMessageLocale(locale, code, field)
 		errs = append(errs, verr.ValidationError{Field: field, Message: msg, Loc: loc, Code: code})
 	}
-	if b.ID == uuid.Nil {
+	if b.ID.IsZero() {
 		req("id", errC.Required)
 	}
-	if b.TenantID == uuid.Nil {
+	if b.TenantID.IsZero() {
 		req("tenant_id", errC.Required)
 	}
 	if b.Name == "" {
@@ -87,10 +130,7 @@ func (b *CoreModel) ValidateWithContext(loc string, code string, locale string)
 		req("modified_at", errC.Required)
 	}
 
-	switch b.Status {
-	case status.Active, status.Deleted, status.Suspended, status.Rejected, status.Draft:
-		// ok
-	default:
+	if _, ok := status.ValidStatus[b.Status]; !ok {
 		req("status", errC.InvalidStatus)
 	}
 
@@ -100,6 +140,7 @@ func (b *CoreModel) ValidateWithContext(loc string, code string, locale string)
 	if err := b.Tags.Validate(); err != nil {
 		req("tags", errC.InvalidJSONFormat)
 	}
+	errs = append(errs, DefaultPolicy.Validate(b, loc, locale)...)
 
 	return errs
 }
@@ -118,11 +159,11 @@ func (b *CoreModel) Validate() []verr.ValidationError {
 // Call this exactly once during entity creation, before persisting.
 // GORM hooks will also set safe defaults if Create is not called, but Create
 // allows you to propagate subject/issuer explicitly.
-func (c *CoreModel) Create(subject, issuer string, tenantID uuid.UUID) {
-	now := Now()
+func (c *CoreModel) Create(subject, issuer string, tenantID ids.TenantID) {
+	now := c.now()
 
-	if c.ID == uuid.Nil {
-		c.ID = uuid.New()
+	if c.ID.IsZero() {
+		c.ID = ids.New[ids.Entity]()
 	}
 	c.TenantID = tenantID
 	c.Issuer = issuer
@@ -131,6 +172,9 @@ func (c *CoreModel) Create(subject, issuer string, tenantID uuid.UUID) {
 	c.CreatedBy = subject
 
 	c.Touch(subject) // sets ModifiedAt/ModifiedBy
+	if c.Revision == 0 {
+		c.Revision = 1
+	}
 
 	// Ensure tenant_id tag is present without mutating a nil map.
 	if c.Tags.Data == nil {
@@ -149,28 +193,57 @@ func (c *CoreModel) Create(subject, issuer string, tenantID uuid.UUID) {
 // Call this prior to persisting updates (e.g., in your service layer). GORM
 // also updates ModifiedAt automatically; this method ensures ModifiedBy too.
 func (c *CoreModel) Touch(subject string) {
-	now := Now()
+	now := c.now()
 	c.ModifiedAt = now
 	c.ModifiedBy = subject
 }
 
+// UseClock makes Create, Touch, Delete and status transitions take their
+// timestamps from ck. GORM hooks prefer a clock in the statement's context
+// (see clock.WithClock) for the duration of that statement.
+func (c *CoreModel) UseClock(ck clock.Clock) {
+	c.clk = ck
+}
+
+func (c *CoreModel) now() time.Time {
+	if c.clk != nil {
+		return c.clk.Now().UTC()
+	}
+	return Now()
+}
+
+// nowIn returns the time of the clock in ctx, falling back to now. The
+// context clock is not kept on the entity.
+func (c *CoreModel) nowIn(ctx context.Context) time.Time {
+	if ck, ok := clock.FromContext(ctx); ok {
+		return ck.Now().UTC()
+	}
+	return c.now()
+}
+
 // BeforeCreate is a GORM hook that applies safe defaults for new rows.
-// It does not set CreatedBy/ModifiedBy (no subject context in hooks).
+// Empty CreatedBy/ModifiedBy, Issuer and TenantID are taken from the
+// statement's context (see WithActor).
 func (b *CoreModel) BeforeCreate(tx *gorm.DB) error {
-	if b.ID == uuid.Nil {
-		b.ID = uuid.New()
+	b.applyActor(tx.Statement.Context)
+	if b.ID.IsZero() {
+		b.ID = ids.New[ids.Entity]()
 	}
 	if b.CreatedAt.IsZero() {
-		b.CreatedAt = Now()
+		b.CreatedAt = b.nowIn(tx.Statement.Context)
 	}
 	if b.ModifiedAt.IsZero() {
 		b.ModifiedAt = b.CreatedAt
 	}
+	if b.Revision == 0 {
+		b.Revision = 1
+	}
+	b.syncDeletion(b.CreatedAt)
 	// Ensure tenant_id tag exists if TenantID is present.
 	if b.Tags.Data == nil {
 		b.Tags.Data = map[string]string{}
 	}
-	if b.TenantID != uuid.Nil {
+	if !b.TenantID.IsZero() {
 		if _, ok := b.Tags.Data["tenant_id"]; !ok {
 			b.Tags.Data["tenant_id"] = b.TenantID.String()
 		}
@@ -178,9 +251,37 @@ func (b *CoreModel) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
-// BeforeUpdate is a GORM hook that refreshes ModifiedAt on updates.
-// (ModifiedBy should be set by callers via Touch to include subject.)
+// BeforeUpdate is a GORM hook that refreshes ModifiedAt on updates, keeps
+// DeletedAt in line with Status and guards the update with the current
+// Revision (see AfterUpdate).
+//
+// ModifiedBy is set from the subject in the statement's context (see
+// WithActor); without one, callers must set it via Touch.
 func (b *CoreModel) BeforeUpdate(tx *gorm.DB) error {
-	b.ModifiedAt = Now()
+	if subject, ok := SubjectFromContext(tx.Statement.Context); ok {
+		b.ModifiedBy = subject
+		tx.Statement.SetColumn("modified_by", subject, true)
+	}
+	b.ModifiedAt = b.nowIn(tx.Statement.Context)
+	b.syncDeletion(b.ModifiedAt)
+	b.guardRevision(tx)
 	return nil
 }
+
+// applyActor fills empty audit and tenancy fields from ctx.
+func (b *CoreModel) applyActor(ctx context.Context) {
+	if subject, ok := SubjectFromContext(ctx); ok {
+		if b.CreatedBy == "" {
+			b.CreatedBy = subject
+		}
+		if b.ModifiedBy == "" {
+			b.ModifiedBy = subject
+		}
+	}
+	if issuer, ok := IssuerFromContext(ctx); ok && b.Issuer == "" {
+		b.Issuer = issuer
+	}
+	if tenantID, ok := TenantFromContext(ctx); ok && b.TenantID.IsZero() {
+		b.TenantID = tenantID
+	}
+}
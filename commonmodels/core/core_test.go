@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/core"
-	"github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/types"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,7 +47,7 @@ func TestCoreModel_Validate_ID_is_required_and_uuid(t *testing.T) {
 	assert.True(t, exist)
 	assert.Equal(t, message, "id is required.")
 
-	coreModel.ID = uuid.New()
+	coreModel.ID = ids.New[ids.Entity]()
 }
 
 func TestCoreModel_Validate_TenantID_is_required_and_uuid(t *testing.T) {
@@ -73,7 +75,7 @@ func TestCoreModel_Validate_TenantID_is_required_and_uuid(t *testing.T) {
 	assert.True(t, exist)
 	assert.Equal(t, message, "tenant_id is required.")
 
-	coreModel.TenantID = uuid.New()
+	coreModel.TenantID = ids.New[ids.Tenant]()
 }
 
 func TestCoreModel_Validate_Name_is_requiredand_string(t *testing.T) {
@@ -229,7 +231,7 @@ func TestCoreModel_Validate_ModifiedAt_is_required_timestamp(t *testing.T) {
 func TestManualLifeCycle_create(t *testing.T) {
 	subject := "user@domain.com"
 	issuer := "grasp-labs"
-	tenantId := uuid.MustParse("25948ccc-cf16-491e-9cd4-44d5ebb7bc54")
+	tenantId := ids.MustParse[ids.Tenant]("25948ccc-cf16-491e-9cd4-44d5ebb7bc54")
 
 	meta := map[string]string{"owner_id": "xyz123", "retention": "365"}
 
@@ -256,7 +258,7 @@ func TestManualLifeCycle_create(t *testing.T) {
 func TestOauthAppIsValid(t *testing.T) {
 	subject := "H8k2mP9vL5rN1wZ7xQ3jT6bY4sF0gA2cD9eR1uM5iV8"
 	issuer := "grasp-labs"
-	tenantId := uuid.MustParse("25948ccc-cf16-491e-9cd4-44d5ebb7bc54")
+	tenantId := ids.MustParse[ids.Tenant]("25948ccc-cf16-491e-9cd4-44d5ebb7bc54")
 
 	meta := map[string]string{"owner_id": "xyz123", "retention": "365"}
 
@@ -279,3 +281,14 @@ func TestOauthAppIsValid(t *testing.T) {
 		t.Fatalf("Expected 0 validationErrors, got %v", validationErrors)
 	}
 }
+
+func TestCreate_uses_ID_generator(t *testing.T) {
+	fixed := uuid.MustParse("0190f3a4-6c1e-7b3a-9a1e-2f4b6c8d0e12")
+	old := ids.NewUUID
+	ids.NewUUID = func() uuid.UUID { return fixed }
+	defer func() { ids.NewUUID = old }()
+
+	var c core.CoreModel
+	c.Create("user@domain.com", "grasp-labs", ids.MustParse[ids.Tenant]("25948ccc-cf16-491e-9cd4-44d5ebb7bc54"))
+	assert.Equal(t, fixed, c.ID.UUID())
+}
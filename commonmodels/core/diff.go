@@ -0,0 +1,127 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	types "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+)
+
+// Change describes a single field that differs between two versions of an
+// entity. Path uses JSON field names joined by dots, e.g. "tags.env".
+//
+// Old is nil for added fields and New is nil for removed fields.
+type Change struct {
+	Path string `json:"path"`
+	Old  any    `json:"old"`
+	New  any    `json:"new"`
+}
+
+// Changes is an ordered (by Path) list of changes.
+//
+// It can be used as-is for audit.AuditEntry.Payload, or via EventPayload for
+// a kafka Event:
+//
+//	changes, err := core.Diff(before, after, "modified_at")
+//	entry.Payload = changes
+//	ev.Payload = changes.EventPayload()
+type Changes []Change
+
+// Paths returns the changed paths.
+func (c Changes) Paths() []string {
+	paths := make([]string, len(c))
+	for i, ch := range c {
+		paths[i] = ch.Path
+	}
+	return paths
+}
+
+// EventPayload wraps the changes as {"changes": [...]} for Event.Payload.
+func (c Changes) EventPayload() *types.JSONB[map[string]any] {
+	return &types.JSONB[map[string]any]{Data: map[string]any{"changes": c}}
+}
+
+// Diff compares two versions of an entity and returns the changed fields.
+//
+// Both values are compared through their JSON representation, so field names
+// follow the json tags and JSONB maps such as Metadata and Tags are compared
+// key by key. Arrays are compared as a whole. A nil before (or after) is
+// treated as an empty object, which lists every field as added (or removed).
+//
+// Paths in ignore are skipped together with everything below them, e.g.
+// "modified_at" or "metadata".
+func Diff[T Entity](before, after T, ignore ...string) (Changes, error) {
+	b, err := toJSONMap(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := toJSONMap(after)
+	if err != nil {
+		return nil, err
+	}
+	var changes Changes
+	diffMaps("", b, a, ignore, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+func toJSONMap(v any) (map[string]any, error) {
+	out := map[string]any{}
+	if rv := reflect.ValueOf(v); !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil()) {
+		return out, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func diffMaps(prefix string, before, after map[string]any, ignore []string, out *Changes) {
+	keys := map[string]struct{}{}
+	for k := range before {
+		keys[k] = struct{}{}
+	}
+	for k := range after {
+		keys[k] = struct{}{}
+	}
+	for k := range keys {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		if ignored(path, ignore) {
+			continue
+		}
+		oldV, newV := before[k], after[k]
+		oldM, oldIsMap := oldV.(map[string]any)
+		newM, newIsMap := newV.(map[string]any)
+		switch {
+		case oldIsMap && newIsMap:
+			diffMaps(path, oldM, newM, ignore, out)
+		case oldIsMap && newV == nil:
+			diffMaps(path, oldM, nil, ignore, out)
+		case newIsMap && oldV == nil:
+			diffMaps(path, nil, newM, ignore, out)
+		case !reflect.DeepEqual(oldV, newV):
+			*out = append(*out, Change{Path: path, Old: oldV, New: newV})
+		}
+	}
+}
+
+func ignored(path string, ignore []string) bool {
+	for _, p := range ignore {
+		if path == p || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package core_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/audit"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+)
+
+func newDataset() *dataset {
+	d := &dataset{
+		CoreModel: core.CoreModel{
+			Name:     "orders",
+			Status:   status.Draft,
+			Metadata: types.JSONB[map[string]string]{Data: map[string]string{"owner_id": "xyz123", "retention": "365"}},
+		},
+		FieldX: "a",
+	}
+	d.Create("user@domain.com", "grasp-labs", tenantA)
+	return d
+}
+
+func TestDiff_changed_fields(t *testing.T) {
+	before := newDataset()
+	after := *before
+	after.Metadata = types.JSONB[map[string]string]{Data: map[string]string{"owner_id": "abc", "region": "eu"}}
+	after.Tags = types.JSONB[map[string]string]{Data: map[string]string{"tenant_id": tenantA.String(), "env": "prod"}}
+	after.Status = status.Active
+	after.FieldX = "b"
+
+	changes, err := core.Diff(before, &after)
+	assert.NoError(t, err)
+	assert.Equal(t, core.Changes{
+		{Path: "field_x", Old: "a", New: "b"},
+		{Path: "metadata.owner_id", Old: "xyz123", New: "abc"},
+		{Path: "metadata.region", Old: nil, New: "eu"},
+		{Path: "metadata.retention", Old: "365", New: nil},
+		{Path: "status", Old: "draft", New: "active"},
+		{Path: "tags.env", Old: nil, New: "prod"},
+	}, changes)
+}
+
+func TestDiff_ignore_and_equal(t *testing.T) {
+	before := newDataset()
+	after := *before
+	after.ModifiedBy = "other@domain.com"
+	after.Metadata = types.JSONB[map[string]string]{Data: map[string]string{"owner_id": "abc"}}
+
+	changes, err := core.Diff(before, &after, "modified_by", "metadata")
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestDiff_nil_before_lists_all_fields(t *testing.T) {
+	after := newDataset()
+
+	changes, err := core.Diff(nil, after)
+	assert.NoError(t, err)
+	assert.Contains(t, changes.Paths(), "id")
+	assert.Contains(t, changes.Paths(), "metadata.owner_id")
+	assert.Contains(t, changes.Paths(), "tags.tenant_id")
+}
+
+func TestDiff_payload_integration(t *testing.T) {
+	before := newDataset()
+	after := *before
+	after.Name = "orders-v2"
+
+	changes, err := core.Diff(before, &after)
+	assert.NoError(t, err)
+
+	entry := audit.AuditEntry{Payload: changes}
+	b, err := json.Marshal(entry)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"payload":[{"path":"name","old":"orders","new":"orders-v2"}]`)
+
+	payload := changes.EventPayload()
+	assert.NoError(t, payload.Validate())
+	b, err = json.Marshal(payload)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"changes":[{"path":"name","old":"orders","new":"orders-v2"}]}`, string(b))
+}
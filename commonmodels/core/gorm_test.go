@@ -0,0 +1,26 @@
+package core_test
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+)
+
+// dataset is a minimal entity embedding CoreModel used by GORM tests.
+type dataset struct {
+	core.CoreModel
+	FieldX string `json:"field_x"`
+}
+
+// dryRunDB opens a GORM handle that only builds SQL.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("failed to open dry-run db: %v", err)
+	}
+	return db
+}
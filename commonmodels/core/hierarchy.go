@@ -0,0 +1,227 @@
+package core
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	sqldialects "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/sql_dialects"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+var (
+	// ErrCycle is returned when a node would become its own ancestor.
+	ErrCycle = errors.New("core: hierarchy cycle")
+	// ErrMaxDepth is returned when a node would exceed MaxDepth.
+	ErrMaxDepth = errors.New("core: hierarchy too deep")
+)
+
+// MaxDepth is the maximum depth of a node; roots have depth 0.
+var MaxDepth = 32
+
+// LTree is a materialized path of node labels joined by dots, root first.
+//
+// Labels are the node IDs as 32 hex characters (no dashes), which are valid
+// Postgres ltree labels and contain no LIKE wildcards. The column is an
+// ltree on Postgres and text elsewhere.
+type LTree string
+
+// Label returns the path label for id.
+func Label(id ids.EntityID) string {
+	return strings.ReplaceAll(id.String(), "-", "")
+}
+
+// Labels splits the path into its labels.
+func (p LTree) Labels() []string {
+	if p == "" {
+		return nil
+	}
+	return strings.Split(string(p), ".")
+}
+
+// Depth returns the number of labels minus one, i.e. 0 for a root.
+func (p LTree) Depth() int {
+	return len(p.Labels()) - 1
+}
+
+// Contains reports whether id is one of the labels of p.
+func (p LTree) Contains(id ids.EntityID) bool {
+	label := Label(id)
+	for _, l := range p.Labels() {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// Ancestors returns the paths of all ancestors, root first.
+func (p LTree) Ancestors() []LTree {
+	labels := p.Labels()
+	out := make([]LTree, 0, len(labels))
+	for i := 1; i < len(labels); i++ {
+		out = append(out, LTree(strings.Join(labels[:i], ".")))
+	}
+	return out
+}
+
+// Value implements driver.Valuer.
+func (p LTree) Value() (driver.Value, error) { return string(p), nil }
+
+// Scan implements sql.Scanner.
+func (p *LTree) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*p = ""
+	case string:
+		*p = LTree(v)
+	case []byte:
+		*p = LTree(v)
+	default:
+		return fmt.Errorf("core: cannot scan %T into LTree", src)
+	}
+	return nil
+}
+
+// GormDataType reports the generic data type.
+func (LTree) GormDataType() string { return "ltree" }
+
+// GormDBDataType uses ltree on Postgres and text elsewhere.
+func (LTree) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if sqldialects.DatabaseDialect(db.Dialector.Name()) == sqldialects.DialectPostgres {
+		return "ltree"
+	}
+	return "text"
+}
+
+// Hierarchy is an optional mixin placing an entity in a tree, e.g. folders
+// or projects. Embed it next to CoreModel and link nodes with SetParent:
+//
+//	type Folder struct {
+//		core.CoreModel
+//		core.Hierarchy
+//	}
+//
+// Path is the materialized path from the root to the node itself and Depth
+// its length minus one. Both are only maintained by SetParent; call
+// SetParent(node, nil) for roots before saving them.
+//
+// The path column carries a plain index so AutoMigrate works on every
+// dialect. On Postgres, SubtreeOf needs a GiST index for <@; the
+// migration package generates it, together with the ltree extension.
+type Hierarchy struct {
+	ParentID *ids.EntityID `gorm:"type:uuid;index" json:"parent_id,omitempty"`
+	Path     LTree         `gorm:"index" json:"path"`
+	Depth    int           `json:"depth"`
+}
+
+// Tree returns the embedded Hierarchy; it satisfies Node.
+func (h *Hierarchy) Tree() *Hierarchy {
+	return h
+}
+
+// Node is implemented by entities embedding both CoreModel and Hierarchy.
+type Node interface {
+	Entity
+	Tree() *Hierarchy
+}
+
+// SetParent moves child below parent, or makes it a root when parent is nil.
+//
+// It returns ErrTenantMismatch if both belong to different tenants, ErrCycle
+// if child is parent or one of its ancestors and ErrMaxDepth if the new depth
+// exceeds MaxDepth. Descendants of child keep their old paths; rewrite them
+// when moving a subtree.
+//
+// Cycle detection relies on the stored Path of parent. Load parent inside
+// the transaction that saves child (e.g. with SELECT ... FOR UPDATE) so a
+// concurrent move cannot leave it stale.
+func SetParent[T Node](child, parent T) error {
+	c := child.Core()
+	if c.ID.IsZero() {
+		c.ID = ids.New[ids.Entity]()
+	}
+	h := child.Tree()
+	if isNilNode(parent) {
+		h.ParentID = nil
+		h.Path = LTree(Label(c.ID))
+		h.Depth = 0
+		return nil
+	}
+	p := parent.Core()
+	if p.TenantID != c.TenantID {
+		return fmt.Errorf("%w: parent %s and child %s", ErrTenantMismatch, p.ID, c.ID)
+	}
+	ph := parent.Tree()
+	parentPath := ph.Path
+	if parentPath == "" {
+		parentPath = LTree(Label(p.ID))
+	}
+	if p.ID == c.ID || parentPath.Contains(c.ID) {
+		return fmt.Errorf("%w: %s is an ancestor of %s", ErrCycle, c.ID, p.ID)
+	}
+	if depth := parentPath.Depth() + 1; depth > MaxDepth {
+		return fmt.Errorf("%w: depth %d exceeds %d", ErrMaxDepth, depth, MaxDepth)
+	}
+	id := p.ID
+	h.ParentID = &id
+	h.Path = parentPath + LTree("."+Label(c.ID))
+	h.Depth = h.Path.Depth()
+	return nil
+}
+
+func isNilNode[T Node](n T) bool {
+	rv := reflect.ValueOf(n)
+	return !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil())
+}
+
+// SubtreeOf is a GORM scope returning the node at path and all its
+// descendants. Postgres uses the ltree <@ operator, other dialects a prefix
+// match.
+//
+//	db.Scopes(core.SubtreeOf(folder.Path)).Find(&folders)
+func SubtreeOf(path LTree) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		col := clause.Column{Table: clause.CurrentTable, Name: "path"}
+		if sqldialects.DatabaseDialect(db.Dialector.Name()) == sqldialects.DialectPostgres {
+			return db.Where(clause.Expr{SQL: "? <@ CAST(? AS ltree)", Vars: []any{col, string(path)}})
+		}
+		return db.Where(clause.Or(
+			clause.Eq{Column: col, Value: string(path)},
+			clause.Like{Column: col, Value: string(path) + ".%"},
+		))
+	}
+}
+
+// AncestorsOf is a GORM scope returning the ancestors of the node at path,
+// excluding the node itself.
+//
+//	db.Scopes(core.AncestorsOf(folder.Path)).Order("depth").Find(&folders)
+func AncestorsOf(path LTree) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		ancestors := path.Ancestors()
+		if len(ancestors) == 0 {
+			return db.Where("1 = 0")
+		}
+		paths := make([]any, len(ancestors))
+		for i, a := range ancestors {
+			paths[i] = string(a)
+		}
+		return db.Where(clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: "path"}, Values: paths})
+	}
+}
+
+// ChildrenOf is a GORM scope returning the direct children of a node.
+//
+//	db.Scopes(core.ChildrenOf(folder.ID)).Find(&folders)
+func ChildrenOf(id ids.EntityID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "parent_id"}, Value: id})
+	}
+}
@@ -0,0 +1,75 @@
+package core_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+)
+
+// folder is an entity placed in a tree.
+type folder struct {
+	core.CoreModel
+	core.Hierarchy
+}
+
+func newFolder() *folder {
+	f := &folder{CoreModel: core.CoreModel{Name: "f"}}
+	f.Create("user@domain.com", "grasp-labs", tenantA)
+	return f
+}
+
+func TestSetParent(t *testing.T) {
+	root, child, leaf := newFolder(), newFolder(), newFolder()
+	assert.NoError(t, core.SetParent(root, nil))
+	assert.NoError(t, core.SetParent(child, root))
+	assert.NoError(t, core.SetParent(leaf, child))
+
+	assert.Equal(t, core.LTree(core.Label(root.ID)), root.Path)
+	assert.Equal(t, 0, root.Depth)
+	assert.Nil(t, root.ParentID)
+
+	assert.Equal(t, child.ID, *leaf.ParentID)
+	assert.Equal(t, 2, leaf.Depth)
+	assert.Equal(t, core.LTree(core.Label(root.ID)+"."+core.Label(child.ID)+"."+core.Label(leaf.ID)), leaf.Path)
+	assert.Equal(t, []core.LTree{root.Path, child.Path}, leaf.Path.Ancestors())
+}
+
+func TestSetParent_rejects_invalid_links(t *testing.T) {
+	root, child := newFolder(), newFolder()
+	assert.NoError(t, core.SetParent(child, root))
+
+	assert.True(t, errors.Is(core.SetParent(root, child), core.ErrCycle))
+	assert.True(t, errors.Is(core.SetParent(root, root), core.ErrCycle))
+
+	other := newFolder()
+	other.TenantID = tenantB
+	assert.True(t, errors.Is(core.SetParent(other, root), core.ErrTenantMismatch))
+
+	old := core.MaxDepth
+	core.MaxDepth = 1
+	defer func() { core.MaxDepth = old }()
+	assert.True(t, errors.Is(core.SetParent(newFolder(), child), core.ErrMaxDepth))
+}
+
+func TestHierarchy_scopes(t *testing.T) {
+	root, child := newFolder(), newFolder()
+	assert.NoError(t, core.SetParent(root, nil))
+	assert.NoError(t, core.SetParent(child, root))
+
+	tx := dialectDB(t, "postgres").Scopes(core.SubtreeOf(root.Path)).Find(&[]folder{})
+	assert.Contains(t, tx.Statement.SQL.String(), "`folders`.`path` <@ CAST(? AS ltree)")
+
+	tx = dryRunDB(t).Scopes(core.SubtreeOf(root.Path)).Find(&[]folder{})
+	assert.Contains(t, tx.Statement.SQL.String(), "(`folders`.`path` = ? OR `folders`.`path` LIKE ?)")
+	assert.Contains(t, tx.Statement.Vars, string(root.Path)+".%")
+
+	tx = dryRunDB(t).Scopes(core.AncestorsOf(child.Path)).Find(&[]folder{})
+	assert.Contains(t, tx.Statement.SQL.String(), "`folders`.`path` = ?")
+	assert.Contains(t, tx.Statement.Vars, core.Label(root.ID))
+
+	tx = dryRunDB(t).Scopes(core.ChildrenOf(root.ID)).Find(&[]folder{})
+	assert.Contains(t, tx.Statement.SQL.String(), "`folders`.`parent_id` = ?")
+}
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	status "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+// StatusChange records a single status change of an entity: who moved it
+// from which status to which, when and why.
+//
+// Changes made through TransitionTo, Transition, TransitionWithReason,
+// Delete and Restore are collected on the CoreModel and written to the
+// status_changes table by StatusHistoryPlugin, which also records soft
+// deletes issued with db.Delete.
+type StatusChange struct {
+	ID         uuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID   ids.TenantID  `gorm:"type:uuid;index" json:"tenant_id"`
+	EntityID   ids.EntityID  `gorm:"type:uuid;index" json:"entity_id"`
+	EntityType string        `json:"entity_type"`
+	From       status.Status `json:"from"`
+	To         status.Status `json:"to"`
+	Actor      string        `json:"actor"`
+	ReasonCode string        `json:"reason_code,omitempty"`
+	Reason     string        `json:"reason,omitempty"`
+	ChangedAt  time.Time     `json:"changed_at"`
+}
+
+// StatusChanges returns the status changes recorded since the entity was
+// loaded (or last persisted by StatusHistoryPlugin), oldest first.
+func (c *CoreModel) StatusChanges() []StatusChange {
+	return c.statusChanges
+}
+
+// ClearStatusChanges forgets the recorded status changes, e.g. after they
+// have been persisted or published.
+func (c *CoreModel) ClearStatusChanges() {
+	c.statusChanges = nil
+}
+
+// recordStatusChange appends a change from one status to another.
+func (c *CoreModel) recordStatusChange(subject string, from, to status.Status, code, reason string) {
+	c.statusChanges = append(c.statusChanges, StatusChange{
+		ID:         ids.NewUUID(),
+		TenantID:   c.TenantID,
+		EntityID:   c.ID,
+		From:       from,
+		To:         to,
+		Actor:      subject,
+		ReasonCode: code,
+		Reason:     reason,
+		ChangedAt:  c.now(),
+	})
+}
+
+// StatusHistoryOf is a GORM scope returning the status history of e, oldest
+// first:
+//
+//	var history []core.StatusChange
+//	db.Scopes(core.StatusHistoryOf(ds)).Find(&history)
+func StatusHistoryOf(e Entity) func(*gorm.DB) *gorm.DB {
+	c := e.Core()
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.Eq{Column: clause.Column{Name: "entity_id"}, Value: c.ID}).
+			Where(clause.Eq{Column: clause.Column{Name: "tenant_id"}, Value: c.TenantID}).
+			Order("changed_at")
+	}
+}
+
+// StatusHistoryPlugin writes the status changes recorded on CoreModel
+// entities to the status_changes table, in the same transaction as the
+// create or update that persists the entity:
+//
+//	db.AutoMigrate(&core.StatusChange{})
+//	db.Use(core.StatusHistoryPlugin{})
+//
+// EntityType is set to the entity's table name when empty.
+type StatusHistoryPlugin struct{}
+
+// Name implements gorm.Plugin.
+func (StatusHistoryPlugin) Name() string { return "core:status_history" }
+
+// Initialize implements gorm.Plugin.
+func (StatusHistoryPlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().After("gorm:create").Register("core:status_history_create", saveStatusChanges); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("core:status_history_update", saveStatusChanges); err != nil {
+		return err
+	}
+	cb := db.Callback().Delete()
+	if err := cb.Before("gorm:delete").Register("core:status_history_soft_delete", recordSoftDelete); err != nil {
+		return err
+	}
+	return cb.After("gorm:delete").Register("core:status_history_delete", saveStatusChanges)
+}
+
+// recordSoftDelete records the move to status.Deleted of entities soft
+// deleted by the statement.
+func recordSoftDelete(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.Unscoped {
+		return
+	}
+	if _, ok := db.Statement.Schema.FieldsByDBName["deleted_at"]; !ok {
+		return
+	}
+	ctx := db.Statement.Context
+	subject, ok := SubjectFromContext(ctx)
+	eachEntity(db.Statement.ReflectValue, func(c *CoreModel) {
+		if c.ID.IsZero() || c.Status == status.Deleted {
+			return
+		}
+		actor := subject
+		if !ok {
+			actor = c.DeletedBy
+		}
+		c.recordStatusChange(actor, c.Status, status.Deleted, "", "")
+		c.statusChanges[len(c.statusChanges)-1].ChangedAt = nowFrom(ctx)
+	})
+}
+
+func saveStatusChanges(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil || db.DryRun {
+		return
+	}
+	var changes []StatusChange
+	var entities []*CoreModel
+	eachEntity(db.Statement.ReflectValue, func(c *CoreModel) {
+		for _, ch := range c.statusChanges {
+			if ch.EntityType == "" {
+				ch.EntityType = db.Statement.Schema.Table
+			}
+			// The ID may only be assigned on create.
+			ch.EntityID, ch.TenantID = c.ID, c.TenantID
+			changes = append(changes, ch)
+		}
+		entities = append(entities, c)
+	})
+	if len(changes) == 0 {
+		return
+	}
+	tx := db.Session(&gorm.Session{NewDB: true, SkipHooks: true})
+	if err := tx.Create(&changes).Error; err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	for _, c := range entities {
+		c.ClearStatusChanges()
+	}
+}
@@ -0,0 +1,124 @@
+package core_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils/tests"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+)
+
+// recordingConnPool records executed statements; every write affects one row.
+type recordingConnPool struct {
+	stmts []string
+}
+
+func (p *recordingConnPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (p *recordingConnPool) ExecContext(_ context.Context, query string, _ ...any) (sql.Result, error) {
+	p.stmts = append(p.stmts, query)
+	return driver.RowsAffected(1), nil
+}
+func (p *recordingConnPool) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, errors.New("not supported")
+}
+func (p *recordingConnPool) QueryRowContext(context.Context, string, ...any) *sql.Row { return nil }
+
+func TestStatusChanges_recorded_by_core_api(t *testing.T) {
+	d := newDataset()
+	assert.NoError(t, d.TransitionTo("owner@domain.com", status.Active))
+	assert.NoError(t, d.TransitionToWithReason("admin@domain.com", status.Suspended, "abuse", "spam reports"))
+	d.Delete("admin@domain.com")
+	assert.NoError(t, d.Restore("admin@domain.com", status.Draft))
+
+	// Rejected transitions are not recorded.
+	assert.Error(t, d.TransitionTo("owner@domain.com", status.Closed))
+
+	changes := d.StatusChanges()
+	assert.Len(t, changes, 4)
+	assert.Equal(t, status.Draft, changes[0].From)
+	assert.Equal(t, status.Active, changes[0].To)
+	assert.Equal(t, "owner@domain.com", changes[0].Actor)
+	assert.Equal(t, "abuse", changes[1].ReasonCode)
+	assert.Equal(t, "spam reports", changes[1].Reason)
+	assert.Equal(t, status.Suspended, changes[2].From)
+	assert.Equal(t, status.Deleted, changes[2].To)
+	assert.Equal(t, status.Draft, changes[3].To)
+	assert.Equal(t, d.ID, changes[3].EntityID)
+	assert.Equal(t, tenantA, changes[3].TenantID)
+
+	d.ClearStatusChanges()
+	assert.Empty(t, d.StatusChanges())
+
+	// Patches changing the status are recorded too.
+	assert.NoError(t, core.ApplyMergePatch(d, []byte(`{"status":"active"}`), "editor@domain.com", "en"))
+	assert.Len(t, d.StatusChanges(), 1)
+	assert.Equal(t, "editor@domain.com", d.StatusChanges()[0].Actor)
+}
+
+func TestStatusHistoryPlugin_persists_changes(t *testing.T) {
+	pool := &recordingConnPool{}
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{
+		ConnPool:               pool,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Use(core.StatusHistoryPlugin{}))
+
+	d := newDataset()
+	assert.NoError(t, d.TransitionToWithReason("admin@domain.com", status.Rejected, "incomplete", ""))
+	assert.NoError(t, db.Save(d).Error)
+
+	assert.Len(t, pool.stmts, 2)
+	assert.Contains(t, pool.stmts[1], "INSERT INTO `status_changes`")
+	assert.Empty(t, d.StatusChanges())
+
+	// Nothing recorded, nothing written.
+	assert.NoError(t, db.Save(d).Error)
+	assert.Len(t, pool.stmts, 3)
+}
+
+func TestStatusHistoryPlugin_records_soft_delete(t *testing.T) {
+	pool := &recordingConnPool{}
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{
+		ConnPool:               pool,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Use(core.StatusHistoryPlugin{}))
+
+	d := newDataset()
+	assert.NoError(t, d.TransitionTo("owner@domain.com", status.Active))
+	d.ClearStatusChanges()
+
+	ctx := core.WithActor(context.Background(), "admin@domain.com", "grasp-labs", tenantA)
+	assert.NoError(t, db.WithContext(ctx).Delete(d).Error)
+
+	assert.Len(t, pool.stmts, 2)
+	assert.Contains(t, pool.stmts[0], "UPDATE `datasets`")
+	assert.Contains(t, pool.stmts[1], "INSERT INTO `status_changes`")
+	assert.Equal(t, status.Deleted, d.Status)
+	assert.Empty(t, d.StatusChanges())
+
+	// Hard deletes have no status to record.
+	assert.NoError(t, db.Unscoped().Delete(newDataset()).Error)
+	assert.Len(t, pool.stmts, 3)
+}
+
+func TestStatusHistoryOf(t *testing.T) {
+	d := newDataset()
+	tx := dryRunDB(t).Scopes(core.StatusHistoryOf(d)).Find(&[]core.StatusChange{})
+	assert.NoError(t, tx.Error)
+	assert.Equal(t, "SELECT * FROM `status_changes` WHERE `entity_id` = ? AND `tenant_id` = ? ORDER BY changed_at", tx.Statement.SQL.String())
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	sqldialects "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/sql_dialects"
+)
+
+// ErrJSONUnsupported is added to the statement when a JSONB scope is used on
+// a dialect without JSON operators.
+var ErrJSONUnsupported = errors.New("core: json filters are not supported by this dialect")
+
+// HasTag is a GORM scope matching rows whose Tags contain key.
+//
+//	db.Scopes(core.HasTag("env")).Find(&datasets)
+func HasTag(key string) func(*gorm.DB) *gorm.DB {
+	return jsonScope("tags", func(d sqldialects.DatabaseDialect, col clause.Column) (clause.Expression, error) {
+		return jsonHasKey(d, col, key)
+	})
+}
+
+// TagEquals is a GORM scope matching rows whose tag key equals value.
+//
+//	db.Scopes(core.TagEquals("env", "prod")).Find(&datasets)
+func TagEquals(key, value string) func(*gorm.DB) *gorm.DB {
+	return TagIn(key, value)
+}
+
+// TagIn is a GORM scope matching rows whose tag key equals any of values.
+// No values matches nothing.
+//
+//	db.Scopes(core.TagIn("env", "dev", "test")).Find(&datasets)
+func TagIn(key string, values ...string) func(*gorm.DB) *gorm.DB {
+	return jsonScope("tags", func(d sqldialects.DatabaseDialect, col clause.Column) (clause.Expression, error) {
+		return jsonValueIn(d, col, key, values)
+	})
+}
+
+// MetadataContains is a GORM scope matching rows whose Metadata contains
+// every key/value pair of subset (Postgres `@>`).
+//
+//	db.Scopes(core.MetadataContains(map[string]string{"owner_id": "xyz123"})).Find(&datasets)
+func MetadataContains(subset map[string]string) func(*gorm.DB) *gorm.DB {
+	return jsonScope("metadata", func(d sqldialects.DatabaseDialect, col clause.Column) (clause.Expression, error) {
+		return jsonContains(d, col, subset)
+	})
+}
+
+// jsonScope builds a scope filtering on a JSONB column of the current table.
+// Unsupported dialects add ErrJSONUnsupported to the statement instead of
+// silently ignoring the filter.
+func jsonScope(column string, build func(sqldialects.DatabaseDialect, clause.Column) (clause.Expression, error)) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		dialect := sqldialects.DatabaseDialect(db.Dialector.Name())
+		expr, err := build(dialect, clause.Column{Table: clause.CurrentTable, Name: column})
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+		return db.Where(expr)
+	}
+}
+
+func jsonHasKey(d sqldialects.DatabaseDialect, col clause.Column, key string) (clause.Expression, error) {
+	switch d {
+	case sqldialects.DialectPostgres:
+		// jsonb_exists is the function behind the `?` operator, which would
+		// otherwise clash with bind placeholders.
+		return clause.Expr{SQL: "jsonb_exists(?, ?)", Vars: []any{col, key}}, nil
+	case sqldialects.DialectMySQL:
+		return clause.Expr{SQL: "JSON_CONTAINS_PATH(?, 'one', ?)", Vars: []any{col, jsonPath(key)}}, nil
+	case sqldialects.DialectSQLite:
+		return clause.Expr{SQL: "json_type(?, ?) IS NOT NULL", Vars: []any{col, jsonPath(key)}}, nil
+	default:
+		return nil, unsupported(d)
+	}
+}
+
+func jsonValueIn(d sqldialects.DatabaseDialect, col clause.Column, key string, values []string) (clause.Expression, error) {
+	var field string
+	var vars []any
+	switch d {
+	case sqldialects.DialectPostgres:
+		field, vars = "? ->> ?", []any{col, key}
+	case sqldialects.DialectMySQL:
+		field, vars = "JSON_UNQUOTE(JSON_EXTRACT(?, ?))", []any{col, jsonPath(key)}
+	case sqldialects.DialectSQLite:
+		field, vars = "json_extract(?, ?)", []any{col, jsonPath(key)}
+	default:
+		return nil, unsupported(d)
+	}
+	switch len(values) {
+	case 0:
+		return clause.Expr{SQL: "1 = 0"}, nil
+	case 1:
+		return clause.Expr{SQL: field + " = ?", Vars: append(vars, values[0])}, nil
+	default:
+		return clause.Expr{SQL: field + " IN ?", Vars: append(vars, values)}, nil
+	}
+}
+
+func jsonContains(d sqldialects.DatabaseDialect, col clause.Column, subset map[string]string) (clause.Expression, error) {
+	doc, err := json.Marshal(subset)
+	if err != nil {
+		return nil, err
+	}
+	switch d {
+	case sqldialects.DialectPostgres:
+		return clause.Expr{SQL: "? @> CAST(? AS jsonb)", Vars: []any{col, string(doc)}}, nil
+	case sqldialects.DialectMySQL:
+		return clause.Expr{SQL: "JSON_CONTAINS(?, ?)", Vars: []any{col, string(doc)}}, nil
+	case sqldialects.DialectSQLite:
+		// No containment operator: compare key by key.
+		keys := make([]string, 0, len(subset))
+		for k := range subset {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		exprs := make([]clause.Expression, 0, len(keys))
+		for _, k := range keys {
+			exprs = append(exprs, clause.Expr{SQL: "json_extract(?, ?) = ?", Vars: []any{col, jsonPath(k), subset[k]}})
+		}
+		return clause.And(exprs...), nil
+	default:
+		return nil, unsupported(d)
+	}
+}
+
+// jsonPath quotes key as a MySQL/SQLite JSON path member, e.g. $."env".
+func jsonPath(key string) string {
+	key = strings.ReplaceAll(key, `\`, `\\`)
+	key = strings.ReplaceAll(key, `"`, `\"`)
+	return `$."` + key + `"`
+}
+
+func unsupported(d sqldialects.DatabaseDialect) error {
+	return fmt.Errorf("%w: %q", ErrJSONUnsupported, d)
+}
@@ -0,0 +1,66 @@
+package core_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+)
+
+// namedDialector is a DummyDialector reporting another dialect name.
+type namedDialector struct {
+	tests.DummyDialector
+	name string
+}
+
+func (d namedDialector) Name() string { return d.name }
+
+func dialectDB(t *testing.T, name string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(namedDialector{name: name}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("failed to open dry-run db: %v", err)
+	}
+	return db
+}
+
+func TestJSONScopes_postgres(t *testing.T) {
+	db := dialectDB(t, "postgres")
+
+	tx := db.Scopes(core.HasTag("env")).Find(&[]dataset{})
+	assert.NoError(t, tx.Error)
+	assert.Contains(t, tx.Statement.SQL.String(), "jsonb_exists(`datasets`.`tags`, ?)")
+	assert.Contains(t, tx.Statement.Vars, "env")
+
+	tx = db.Scopes(core.TagEquals("env", "prod")).Find(&[]dataset{})
+	assert.Contains(t, tx.Statement.SQL.String(), "`datasets`.`tags` ->> ? = ?")
+	assert.Contains(t, tx.Statement.Vars, "prod")
+
+	tx = db.Scopes(core.TagIn("env", "dev", "test")).Find(&[]dataset{})
+	assert.Contains(t, tx.Statement.SQL.String(), "`datasets`.`tags` ->> ? IN (?,?)")
+
+	tx = db.Scopes(core.MetadataContains(map[string]string{"owner_id": "x'; drop table datasets; --"})).Find(&[]dataset{})
+	assert.NoError(t, tx.Error)
+	assert.Contains(t, tx.Statement.SQL.String(), "`datasets`.`metadata` @> CAST(? AS jsonb)")
+	assert.NotContains(t, tx.Statement.SQL.String(), "drop table")
+	assert.Contains(t, tx.Statement.Vars, `{"owner_id":"x'; drop table datasets; --"}`)
+}
+
+func TestJSONScopes_mysql_and_sqlite(t *testing.T) {
+	tx := dialectDB(t, "mysql").Scopes(core.TagEquals(`e"nv`, "prod")).Find(&[]dataset{})
+	assert.Contains(t, tx.Statement.SQL.String(), "JSON_UNQUOTE(JSON_EXTRACT(`datasets`.`tags`, ?)) = ?")
+	assert.Contains(t, tx.Statement.Vars, `$."e\"nv"`)
+
+	tx = dialectDB(t, "sqlite").Scopes(core.MetadataContains(map[string]string{"b": "2", "a": "1"})).Find(&[]dataset{})
+	assert.Contains(t, tx.Statement.SQL.String(), "json_extract(`datasets`.`metadata`, ?) = ? AND json_extract(`datasets`.`metadata`, ?) = ?")
+	assert.Equal(t, []any{`$."a"`, "1", `$."b"`, "2"}, tx.Statement.Vars[:4])
+}
+
+func TestJSONScopes_unsupported_dialect(t *testing.T) {
+	tx := dialectDB(t, "sqlserver").Scopes(core.HasTag("env")).Find(&[]dataset{})
+	assert.True(t, errors.Is(tx.Error, core.ErrJSONUnsupported))
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"errors"
+
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	types "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+	verr "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
+)
+
+// Localized is an optional mixin adding per-locale names and descriptions
+// next to CoreModel.Name and Description, which remain the canonical
+// values:
+//
+//	type Dataset struct {
+//		core.CoreModel
+//		core.Localized
+//	}
+//
+//	ds.NameIn("nb", ds.Name) // "Ordrer", or ds.Name when untranslated
+type Localized struct {
+	LocalizedName        types.LocalizedText `gorm:"type:jsonb" json:"localized_name,omitempty"`
+	LocalizedDescription types.LocalizedText `gorm:"type:jsonb" json:"localized_description,omitempty"`
+}
+
+// NameIn resolves the name for locale, returning fallback when no locale
+// (including the fallback chain) has a text.
+func (l *Localized) NameIn(locale, fallback string) string {
+	if s, ok := l.LocalizedName.Resolve(locale); ok {
+		return s
+	}
+	return fallback
+}
+
+// DescriptionIn is like NameIn for the description.
+func (l *Localized) DescriptionIn(locale, fallback string) string {
+	if s, ok := l.LocalizedDescription.Resolve(locale); ok {
+		return s
+	}
+	return fallback
+}
+
+// ValidateLocalized checks that the localized fields, when set, are keyed by
+// locale tags and have a text for at least one locale.
+func (l *Localized) ValidateLocalized(loc, locale string) []verr.ValidationError {
+	if locale == "" {
+		locale = "en"
+	}
+	var errs []verr.ValidationError
+	check := func(field string, t types.LocalizedText) {
+		if t == nil {
+			return
+		}
+		code := errC.Required
+		switch err := t.Validate(); {
+		case err == nil:
+			return
+		case errors.Is(err, types.ErrInvalidLocale):
+			code = errC.InvalidKey
+		}
+		msg := errC.HumanMessageLocale(locale, code, field)
+		errs = append(errs, verr.ValidationError{Field: field, Message: msg, Loc: loc, Code: code})
+	}
+	check("localized_name", l.LocalizedName)
+	check("localized_description", l.LocalizedDescription)
+	return errs
+}
@@ -0,0 +1,67 @@
+package core_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+)
+
+// product is an entity with localized texts.
+type product struct {
+	core.CoreModel
+	core.Localized
+}
+
+func TestLocalizedText_Resolve(t *testing.T) {
+	text := types.LocalizedText{"en": "Orders", "nb": "Ordrer"}
+
+	s, ok := text.Resolve("nb")
+	assert.True(t, ok)
+	assert.Equal(t, "Ordrer", s)
+
+	s, _ = text.Resolve("nb-NO")
+	assert.Equal(t, "Ordrer", s)
+	s, _ = text.Resolve("nn")
+	assert.Equal(t, "Ordrer", s)
+	s, _ = text.Resolve("de")
+	assert.Equal(t, "Orders", s)
+
+	_, ok = types.LocalizedText{"de": "Bestellungen"}.Resolve("nb")
+	assert.False(t, ok)
+
+	assert.Equal(t, "Ordrer", text.Render("nb"))
+	assert.Equal(t, map[string]string{"en": "Orders", "nb": "Ordrer"}, text.Render(""))
+	b, err := json.Marshal(map[string]any{"name": text.Render("*")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":{"en":"Orders","nb":"Ordrer"}}`, string(b))
+}
+
+func TestLocalized_mixin(t *testing.T) {
+	p := &product{CoreModel: core.CoreModel{Name: "orders"}}
+	assert.Equal(t, "orders", p.NameIn("nb", p.Name))
+	assert.Empty(t, p.ValidateLocalized("body", "en"))
+
+	p.LocalizedName = types.LocalizedText{"nb": "Ordrer"}
+	assert.Equal(t, "Ordrer", p.NameIn("nb", p.Name))
+	assert.Equal(t, "orders", p.NameIn("en", p.Name))
+
+	p.LocalizedDescription = types.LocalizedText{"en": " "}
+	p.LocalizedName["Not A Locale"] = "x"
+	errs := p.ValidateLocalized("body", "en")
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "localized_name", errs[0].Field)
+	assert.Equal(t, errC.InvalidKey, errs[0].Code)
+	assert.Equal(t, "localized_description", errs[1].Field)
+	assert.Equal(t, errC.Required, errs[1].Code)
+
+	v, err := p.LocalizedDescription.Value()
+	assert.NoError(t, err)
+	var back types.LocalizedText
+	assert.NoError(t, back.Scan(v))
+	assert.Equal(t, p.LocalizedDescription, back)
+}
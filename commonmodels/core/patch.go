@@ -0,0 +1,442 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/decoder"
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	verr "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
+)
+
+// ImmutableFields lists the JSON fields owned by the server. Patches that
+// change any of them are rejected with errC.ImmutableField.
+var ImmutableFields = []string{
+	"id", "tenant_id", "created_at", "created_by", "issuer",
+	"revision", "modified_at", "modified_by", "deleted_at", "deleted_by",
+}
+
+// errPatchPath is returned when a JSON pointer does not resolve.
+var errPatchPath = errors.New("path not found")
+
+// patchOperation is a single RFC 6902 operation.
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ApplyMergePatch applies an RFC 7396 JSON Merge Patch to entity.
+//
+// On success the entity is updated in place and Touch(subject) is called.
+// Otherwise entity is left unchanged and a *validation_error.ErrorEnvelope
+// is returned describing each violation with its JSON path, including the
+// errors of Validate on the patched entity, e.g.:
+//
+//	if err := core.ApplyMergePatch(ds, body, subject, "en"); err != nil {
+//		if envelope, ok := verr.Extract(err); ok {
+//			return c.JSON(http.StatusUnprocessableEntity, envelope)
+//		}
+//	}
+//
+// A status change must be allowed by TransitionsFor(entity); otherwise a
+// *TransitionError (errC.Conflict) is returned.
+func ApplyMergePatch[T Entity](entity T, patch []byte, subject, locale string) error {
+	p, err := decodeJSON(patch)
+	if err != nil {
+		return patchEnvelope(locale, "", errC.InvalidJSONFormat)
+	}
+	obj, ok := p.(map[string]any)
+	if !ok {
+		return patchEnvelope(locale, "", errC.InvalidPatch)
+	}
+	return applyPatch(entity, subject, locale, func(doc map[string]any) (map[string]any, *verr.ErrorEnvelope) {
+		mergePatch(doc, obj)
+		return doc, nil
+	})
+}
+
+// ApplyJSONPatch applies an RFC 6902 JSON Patch (add, remove, replace, move,
+// copy and test) to entity.
+//
+// Operations are applied in order and atomically: on the first failing
+// operation entity is left unchanged. Errors are reported the same way as
+// ApplyMergePatch, with errC.InvalidPatch for operations that cannot be
+// applied (unknown path, failed test, ...).
+func ApplyJSONPatch[T Entity](entity T, patch []byte, subject, locale string) error {
+	var ops []patchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return patchEnvelope(locale, "", errC.InvalidJSONFormat)
+	}
+	return applyPatch(entity, subject, locale, func(doc map[string]any) (map[string]any, *verr.ErrorEnvelope) {
+		var root any = doc
+		for _, op := range ops {
+			next, err := applyOperation(root, op)
+			if err != nil {
+				return nil, patchEnvelope(locale, pointerToField(op.Path), errC.InvalidPatch)
+			}
+			root = next
+		}
+		result, ok := root.(map[string]any)
+		if !ok {
+			return nil, patchEnvelope(locale, "", errC.InvalidPatch)
+		}
+		return result, nil
+	})
+}
+
+// applyPatch runs fn on the JSON form of entity, rejects changes to
+// ImmutableFields, illegal status transitions and invalid results, and
+// decodes the returned document back into entity.
+func applyPatch[T Entity](entity T, subject, locale string, fn func(map[string]any) (map[string]any, *verr.ErrorEnvelope)) error {
+	before, err := toJSONMap(entity)
+	if err != nil {
+		return err
+	}
+	doc, err := toJSONMap(entity)
+	if err != nil {
+		return err
+	}
+	doc, envelope := fn(doc)
+	if envelope != nil {
+		return envelope
+	}
+
+	envelope = verr.New()
+	for _, field := range ImmutableFields {
+		if !reflect.DeepEqual(before[field], doc[field]) {
+			envelope.Append(patchError(locale, field, errC.ImmutableField))
+		}
+	}
+	if len(envelope.Details) > 0 {
+		return envelope
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	target := reflect.ValueOf(entity).Elem()
+	patched := reflect.New(target.Type())
+	if err := json.Unmarshal(b, patched.Interface()); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return patchEnvelope(locale, decoder.TypeErrorField(doc, target.Type(), typeErr), errC.InvalidDataType)
+		}
+		return patchEnvelope(locale, "", errC.InvalidJSONFormat)
+	}
+	c := entity.Core()
+	pc := patched.Interface().(Entity).Core()
+	from, to := c.Status, pc.Status
+	if to != from && !TransitionsFor(entity).Allows(from, to) {
+		return &TransitionError{From: from, To: to}
+	}
+	if errs := pc.ValidateWithContext(string(verr.Body), "", locale); len(errs) > 0 {
+		envelope.Details = errs
+		return envelope
+	}
+	overlayJSONFields(target, patched.Elem())
+	if to != from {
+		c.recordStatusChange(subject, from, to, "", "")
+	}
+	c.Touch(subject)
+	return nil
+}
+
+// overlayJSONFields copies every field with a JSON representation from src
+// to dst, leaving unexported and `json:"-"` fields untouched.
+func overlayJSONFields(dst, src reflect.Value) {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		_, tagged := f.Tag.Lookup("json")
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && !tagged {
+			overlayJSONFields(dst.Field(i), src.Field(i))
+			continue
+		}
+		if !f.IsExported() || f.Tag.Get("json") == "-" {
+			continue
+		}
+		dst.Field(i).Set(src.Field(i))
+	}
+}
+
+func mergePatch(target, patch map[string]any) {
+	for k, v := range patch {
+		if v == nil {
+			delete(target, k)
+			continue
+		}
+		if pv, ok := v.(map[string]any); ok {
+			tv, ok := target[k].(map[string]any)
+			if !ok {
+				tv = map[string]any{}
+			}
+			mergePatch(tv, pv)
+			target[k] = tv
+			continue
+		}
+		target[k] = v
+	}
+}
+
+func applyOperation(root any, op patchOperation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	value := func() (any, error) {
+		if op.Value == nil {
+			return nil, errors.New("value required")
+		}
+		return decodeJSON(op.Value)
+	}
+	switch op.Op {
+	case "add":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(root, path, v)
+	case "remove":
+		root, _, err := pointerRemove(root, path)
+		return root, err
+	case "replace":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		if root, _, err = pointerRemove(root, path); err != nil {
+			return nil, err
+		}
+		return pointerAdd(root, path, v)
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		var v any
+		if op.Op == "move" {
+			if strings.HasPrefix(op.Path, op.From+"/") {
+				return nil, errors.New("cannot move into own child")
+			}
+			if root, v, err = pointerRemove(root, from); err != nil {
+				return nil, err
+			}
+		} else {
+			if v, err = pointerGet(root, from); err != nil {
+				return nil, err
+			}
+			if v, err = deepCopyJSON(v); err != nil {
+				return nil, err
+			}
+		}
+		return pointerAdd(root, path, v)
+	case "test":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		current, err := pointerGet(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, v) {
+			return nil, errors.New("test failed")
+		}
+		return root, nil
+	default:
+		return nil, errors.New("unknown op")
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON pointer into unescaped tokens.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, errPatchPath
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// pointerToField converts a JSON pointer to the dotted field notation used by
+// ValidationError.Field.
+func pointerToField(p string) string {
+	tokens, err := parsePointer(p)
+	if err != nil {
+		return p
+	}
+	return strings.Join(tokens, ".")
+}
+
+func arrayIndex(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max || (len(token) > 1 && token[0] == '0') {
+		return 0, errPatchPath
+	}
+	return i, nil
+}
+
+func pointerGet(node any, path []string) (any, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			v, ok := n[token]
+			if !ok {
+				return nil, errPatchPath
+			}
+			node = v
+		case []any:
+			i, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, errPatchPath
+		}
+	}
+	return node, nil
+}
+
+func pointerAdd(node any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	token, rest := path[0], path[1:]
+	switch n := node.(type) {
+	case map[string]any:
+		if len(rest) == 0 {
+			n[token] = value
+			return n, nil
+		}
+		child, ok := n[token]
+		if !ok {
+			return nil, errPatchPath
+		}
+		child, err := pointerAdd(child, rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = child
+		return n, nil
+	case []any:
+		if len(rest) == 0 {
+			if token == "-" {
+				return append(n, value), nil
+			}
+			i, err := arrayIndex(token, len(n))
+			if err != nil {
+				return nil, err
+			}
+			n = append(n, nil)
+			copy(n[i+1:], n[i:])
+			n[i] = value
+			return n, nil
+		}
+		i, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		child, err := pointerAdd(n[i], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+		return n, nil
+	default:
+		return nil, errPatchPath
+	}
+}
+
+func pointerRemove(node any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, errPatchPath
+	}
+	token, rest := path[0], path[1:]
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[token]
+		if !ok {
+			return nil, nil, errPatchPath
+		}
+		if len(rest) == 0 {
+			delete(n, token)
+			return n, child, nil
+		}
+		child, removed, err := pointerRemove(child, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[token] = child
+		return n, removed, nil
+	case []any:
+		i, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(rest) == 0 {
+			removed := n[i]
+			return append(n[:i:i], n[i+1:]...), removed, nil
+		}
+		child, removed, err := pointerRemove(n[i], rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[i] = child
+		return n, removed, nil
+	default:
+		return nil, nil, errPatchPath
+	}
+}
+
+func decodeJSON(b []byte) (any, error) {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func deepCopyJSON(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(b)
+}
+
+func patchError(locale, field, code string) verr.ValidationError {
+	if locale == "" {
+		locale = "en"
+	}
+	var msg string
+	switch {
+	case code == errC.InvalidJSONFormat:
+		msg = errC.HumanMessageLocale(locale, code)
+	case field == "":
+		msg = errC.HumanMessageLocale(locale, code, string(verr.Body))
+	default:
+		msg = errC.HumanMessageLocale(locale, code, field)
+	}
+	return verr.ValidationError{Field: field, Message: msg, Loc: string(verr.Body), Code: code}
+}
+
+func patchEnvelope(locale, field, code string) *verr.ErrorEnvelope {
+	envelope := verr.New()
+	envelope.Append(patchError(locale, field, code))
+	return envelope
+}
@@ -0,0 +1,172 @@
+package core_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+	verr "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
+)
+
+func envelopeOf(t *testing.T, err error) *verr.ErrorEnvelope {
+	t.Helper()
+	envelope, ok := verr.Extract(err)
+	if !ok {
+		t.Fatalf("expected *ErrorEnvelope, got %v", err)
+	}
+	return envelope
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	fixed := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	d := newDataset()
+	d.UseClock(clock.NewFake(fixed))
+	id := d.ID
+	patch := []byte(`{"name":"orders-v2","field_x":"b","metadata":{"retention":null,"region":"eu"},"status":"active"}`)
+
+	err := core.ApplyMergePatch(d, patch, "editor@domain.com", "en")
+	assert.NoError(t, err)
+	assert.Equal(t, id, d.ID)
+	assert.Equal(t, "orders-v2", d.Name)
+	assert.Equal(t, "b", d.FieldX)
+	assert.Equal(t, status.Active, d.Status)
+	assert.Equal(t, map[string]string{"owner_id": "xyz123", "region": "eu"}, d.Metadata.Data)
+	assert.Equal(t, "editor@domain.com", d.ModifiedBy)
+	assert.Equal(t, fixed, d.ModifiedAt)
+}
+
+func TestApplyMergePatch_immutable_fields(t *testing.T) {
+	d := newDataset()
+	before := *d
+	patch := []byte(`{"name":"x","id":"` + uuid.NewString() + `","tenant_id":"` + tenantB.String() + `","created_by":"me@domain.com"}`)
+
+	err := core.ApplyMergePatch(d, patch, "editor@domain.com", "nb")
+	envelope := envelopeOf(t, err)
+	assert.Len(t, envelope.Details, 3)
+	fields := map[string]string{}
+	for _, e := range envelope.Details {
+		fields[e.Field] = e.Message
+		assert.Equal(t, errC.ImmutableField, e.Code)
+		assert.Equal(t, "body", e.Loc)
+	}
+	assert.Equal(t, "tenant_id kan ikke endres.", fields["tenant_id"])
+	assert.Contains(t, fields, "id")
+	assert.Contains(t, fields, "created_by")
+
+	// Unchanged on failure.
+	assert.Equal(t, before, *d)
+
+	// Re-sending the current value is not a change.
+	err = core.ApplyMergePatch(d, []byte(`{"id":"`+d.ID.String()+`","name":"y"}`), "editor@domain.com", "en")
+	assert.NoError(t, err)
+	assert.Equal(t, "y", d.Name)
+}
+
+func TestApplyMergePatch_server_owned_fields(t *testing.T) {
+	d := newDataset()
+	before := *d
+
+	err := core.ApplyMergePatch(d, []byte(`{"revision":99,"deleted_by":"x","deleted_at":"2025-08-18T12:00:00Z"}`), "editor@domain.com", "en")
+	envelope := envelopeOf(t, err)
+	fields := map[string]string{}
+	for _, e := range envelope.Details {
+		fields[e.Field] = e.Code
+	}
+	assert.Equal(t, map[string]string{
+		"revision":   errC.ImmutableField,
+		"deleted_by": errC.ImmutableField,
+		"deleted_at": errC.ImmutableField,
+	}, fields)
+	assert.Equal(t, before, *d)
+}
+
+func TestApplyMergePatch_status_transition(t *testing.T) {
+	d := newDataset()
+	before := *d
+
+	err := core.ApplyMergePatch(d, []byte(`{"status":"closed"}`), "editor@domain.com", "en")
+	var te *core.TransitionError
+	assert.True(t, errors.As(err, &te))
+	assert.Equal(t, errC.Conflict, te.Code())
+	assert.Equal(t, status.Draft, te.From)
+	assert.Equal(t, status.Closed, te.To)
+	assert.Equal(t, before, *d)
+}
+
+func TestApplyMergePatch_validates_result(t *testing.T) {
+	d := newDataset()
+	before := *d
+
+	err := core.ApplyMergePatch(d, []byte(`{"name":"","tags":{"tenant_id":"other"}}`), "editor@domain.com", "en")
+	envelope := envelopeOf(t, err)
+	fields := map[string]string{}
+	for _, e := range envelope.Details {
+		fields[e.Field] = e.Code
+		assert.Equal(t, "body", e.Loc)
+	}
+	assert.Equal(t, errC.Required, fields["name"])
+	assert.Equal(t, errC.TenantMismatch, fields["tags.tenant_id"])
+	assert.Equal(t, before, *d)
+}
+
+func TestApplyMergePatch_invalid(t *testing.T) {
+	d := newDataset()
+
+	envelope := envelopeOf(t, core.ApplyMergePatch(d, []byte(`{"name":`), "editor@domain.com", "en"))
+	assert.Equal(t, errC.InvalidJSONFormat, envelope.Details[0].Code)
+
+	envelope = envelopeOf(t, core.ApplyMergePatch(d, []byte(`{"metadata":{"owner_id":1}}`), "editor@domain.com", "en"))
+	assert.Equal(t, errC.InvalidDataType, envelope.Details[0].Code)
+	assert.Equal(t, "metadata.owner_id", envelope.Details[0].Field)
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	d := newDataset()
+	patch := []byte(`[
+		{"op":"test","path":"/name","value":"orders"},
+		{"op":"replace","path":"/name","value":"orders-v2"},
+		{"op":"add","path":"/tags/env","value":"prod"},
+		{"op":"remove","path":"/metadata/retention"},
+		{"op":"copy","from":"/metadata/owner_id","path":"/tags/owner"},
+		{"op":"move","from":"/field_x","path":"/description"}
+	]`)
+
+	err := core.ApplyJSONPatch(d, patch, "editor@domain.com", "en")
+	assert.NoError(t, err)
+	assert.Equal(t, "orders-v2", d.Name)
+	assert.Equal(t, "a", d.Description)
+	assert.Equal(t, "", d.FieldX)
+	assert.Equal(t, map[string]string{"owner_id": "xyz123"}, d.Metadata.Data)
+	assert.Equal(t, "prod", d.Tags.Data["env"])
+	assert.Equal(t, "xyz123", d.Tags.Data["owner"])
+	assert.Equal(t, "editor@domain.com", d.ModifiedBy)
+}
+
+func TestApplyJSONPatch_failures(t *testing.T) {
+	d := newDataset()
+	before := *d
+
+	err := core.ApplyJSONPatch(d, []byte(`[{"op":"replace","path":"/name","value":"x"},{"op":"test","path":"/name","value":"y"}]`), "editor@domain.com", "en")
+	envelope := envelopeOf(t, err)
+	assert.Equal(t, errC.InvalidPatch, envelope.Details[0].Code)
+	assert.Equal(t, "name", envelope.Details[0].Field)
+	assert.Equal(t, before, *d)
+
+	err = core.ApplyJSONPatch(d, []byte(`[{"op":"remove","path":"/metadata/missing"}]`), "editor@domain.com", "en")
+	envelope = envelopeOf(t, err)
+	assert.Equal(t, "metadata.missing", envelope.Details[0].Field)
+
+	err = core.ApplyJSONPatch(d, []byte(`[{"op":"remove","path":"/tenant_id"}]`), "editor@domain.com", "en")
+	envelope = envelopeOf(t, err)
+	assert.Equal(t, errC.ImmutableField, envelope.Details[0].Code)
+	assert.Equal(t, "tenant_id", envelope.Details[0].Field)
+	assert.Equal(t, before, *d)
+}
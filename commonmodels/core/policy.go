@@ -0,0 +1,132 @@
+package core
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	verr "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
+)
+
+// MapPolicy constrains the keys and values of a Metadata or Tags map.
+// Zero values disable the corresponding check.
+type MapPolicy struct {
+	// KeyPattern every key must match.
+	KeyPattern *regexp.Regexp
+	// MaxKeyLength and MaxValueLength are measured in characters.
+	MaxKeyLength   int
+	MaxValueLength int
+	// MaxEntries is the maximum number of keys.
+	MaxEntries int
+	// ReservedPrefixes are key prefixes only the server may set (see
+	// Policy.ValidateInput).
+	ReservedPrefixes []string
+}
+
+// Policy holds the MapPolicy for Metadata and Tags.
+type Policy struct {
+	Metadata MapPolicy
+	Tags     MapPolicy
+}
+
+// DefaultPolicy is applied by CoreModel.ValidateWithContext. Services may
+// replace it at start-up to tighten or relax the rules.
+var DefaultPolicy = Policy{
+	Metadata: MapPolicy{
+		KeyPattern:       regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:/-]*$`),
+		MaxKeyLength:     128,
+		MaxValueLength:   1024,
+		MaxEntries:       64,
+		ReservedPrefixes: []string{"grasp:"},
+	},
+	Tags: MapPolicy{
+		KeyPattern:       regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:/-]*$`),
+		MaxKeyLength:     64,
+		MaxValueLength:   256,
+		MaxEntries:       32,
+		ReservedPrefixes: []string{"grasp:", "tenant_id"},
+	},
+}
+
+// Validate checks the Metadata and Tags of c against the policy and that the
+// tenant_id tag, when present, matches TenantID.
+//
+// Reserved prefixes are not checked since stored entities legitimately carry
+// server-set keys; use ValidateInput for client supplied maps.
+func (p Policy) Validate(c *CoreModel, loc, locale string) []verr.ValidationError {
+	if locale == "" {
+		locale = "en"
+	}
+	var errs []verr.ValidationError
+	errs = append(errs, p.Metadata.validate("metadata", c.Metadata.Data, false, loc, locale)...)
+	errs = append(errs, p.Tags.validate("tags", c.Tags.Data, false, loc, locale)...)
+	if tag, ok := c.Tags.Data["tenant_id"]; ok && !c.TenantID.IsZero() && tag != c.TenantID.String() {
+		errs = append(errs, policyError("tags.tenant_id", errC.TenantMismatch, loc, locale))
+	}
+	return errs
+}
+
+// ValidateInput checks client supplied metadata and tags before they are
+// merged into an entity, including ReservedPrefixes:
+//
+//	if errs := core.DefaultPolicy.ValidateInput(req.Metadata, req.Tags, "body", locale); len(errs) > 0 {
+//		return c.JSON(http.StatusBadRequest, errs)
+//	}
+func (p Policy) ValidateInput(metadata, tags map[string]string, loc, locale string) []verr.ValidationError {
+	if locale == "" {
+		locale = "en"
+	}
+	var errs []verr.ValidationError
+	errs = append(errs, p.Metadata.validate("metadata", metadata, true, loc, locale)...)
+	errs = append(errs, p.Tags.validate("tags", tags, true, loc, locale)...)
+	return errs
+}
+
+func (m MapPolicy) validate(field string, data map[string]string, reserved bool, loc, locale string) []verr.ValidationError {
+	var errs []verr.ValidationError
+	if m.MaxEntries > 0 && len(data) > m.MaxEntries {
+		errs = append(errs, policyError(field, errC.TooManyEntries, loc, locale, m.MaxEntries))
+	}
+	// Sorted for stable error output.
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		path := field + "." + k
+		switch {
+		case m.MaxKeyLength > 0 && utf8.RuneCountInString(k) > m.MaxKeyLength:
+			errs = append(errs, policyError(path, errC.KeyTooLong, loc, locale, m.MaxKeyLength))
+		case m.KeyPattern != nil && !m.KeyPattern.MatchString(k):
+			errs = append(errs, policyError(path, errC.InvalidKey, loc, locale))
+		case reserved && hasAnyPrefix(k, m.ReservedPrefixes):
+			errs = append(errs, policyError(path, errC.ReservedKey, loc, locale))
+		}
+		if m.MaxValueLength > 0 && utf8.RuneCountInString(data[k]) > m.MaxValueLength {
+			errs = append(errs, policyError(path, errC.ValueTooLong, loc, locale, m.MaxValueLength))
+		}
+	}
+	return errs
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func policyError(field, code, loc, locale string, limit ...int) verr.ValidationError {
+	args := []any{field}
+	for _, l := range limit {
+		args = append(args, strconv.Itoa(l))
+	}
+	msg := errC.HumanMessageLocale(locale, code, args...)
+	return verr.ValidationError{Field: field, Message: msg, Loc: loc, Code: code}
+}
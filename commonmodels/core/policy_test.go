@@ -0,0 +1,61 @@
+package core_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+)
+
+func TestPolicy_validate_entity(t *testing.T) {
+	d := newDataset()
+	assert.Empty(t, d.Validate())
+
+	d.Tags.Data["tenant_id"] = tenantB.String()
+	d.Tags.Data["bad key"] = "x"
+	d.Metadata.Data["owner_id"] = strings.Repeat("x", 1025)
+
+	errs := d.ValidateWithContext("body", "", "nb")
+	assert.Len(t, errs, 3)
+	got := map[string]string{}
+	for _, e := range errs {
+		got[e.Field] = e.Code
+	}
+	assert.Equal(t, map[string]string{
+		"metadata.owner_id": errC.ValueTooLong,
+		"tags.bad key":      errC.InvalidKey,
+		"tags.tenant_id":    errC.TenantMismatch,
+	}, got)
+	assert.Equal(t, "metadata.owner_id kan ha maks 1024 tegn.", errs[0].Message)
+}
+
+func TestPolicy_validate_input(t *testing.T) {
+	p := core.Policy{Tags: core.MapPolicy{
+		KeyPattern:       regexp.MustCompile(`^[a-z]+$`),
+		MaxKeyLength:     5,
+		MaxEntries:       2,
+		ReservedPrefixes: []string{"sys"},
+	}}
+	errs := p.ValidateInput(nil, map[string]string{"system": "x", "sysx": "y", "Env": "z"}, "body", "en")
+	assert.Len(t, errs, 4)
+	assert.Equal(t, errC.TooManyEntries, errs[0].Code)
+	assert.Equal(t, "tags must have at most 2 entries.", errs[0].Message)
+	assert.Equal(t, []string{"tags.Env", "tags.system", "tags.sysx"}, []string{errs[1].Field, errs[2].Field, errs[3].Field})
+	assert.Equal(t, []string{errC.InvalidKey, errC.KeyTooLong, errC.ReservedKey}, []string{errs[1].Code, errs[2].Code, errs[3].Code})
+
+	// Stored entities may carry server-set keys.
+	d := newDataset()
+	assert.Empty(t, core.DefaultPolicy.Validate(d.Core(), "body", "en"))
+	errs = core.DefaultPolicy.ValidateInput(nil, d.Tags.Data, "body", "en")
+	assert.Equal(t, errC.ReservedKey, errs[0].Code)
+	assert.Equal(t, "tags.tenant_id", errs[0].Field)
+
+	// A zero policy accepts anything.
+	d.Metadata = types.JSONB[map[string]string]{Data: map[string]string{"": strings.Repeat("x", 5000)}}
+	assert.Empty(t, core.Policy{}.Validate(d.Core(), "body", "en"))
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	httperror "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/http_error"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+// ErrRevisionConflict is a sentinel error used to identify optimistic
+// concurrency failures.
+//
+//	errors.Is(err, core.ErrRevisionConflict)
+//
+// The concrete error will be *RevisionConflictError.
+var ErrRevisionConflict = errors.New("revision conflict")
+
+// revisionSettingKey stores the expected revision on the update statement.
+const revisionSettingKey = "core:expected_revision"
+
+// RevisionConflictError reports that an entity was changed by someone else.
+//
+// Fields:
+//   - ID:           the entity id.
+//   - Expected:     revision the caller based its change on.
+//   - Current:      revision currently persisted (0 if unknown).
+//   - Precondition: true when raised by CheckRevision (client supplied
+//     revision, e.g. If-Match); maps to 412 instead of 409.
+type RevisionConflictError struct {
+	ID           ids.EntityID
+	Expected     int64
+	Current      int64
+	Precondition bool
+}
+
+// Error implements the error interface.
+func (e *RevisionConflictError) Error() string {
+	return fmt.Sprintf("%s: %s expected revision %d, current %d", ErrRevisionConflict, e.ID, e.Expected, e.Current)
+}
+
+// Is allows errors.Is(err, ErrRevisionConflict).
+func (e *RevisionConflictError) Is(target error) bool { return target == ErrRevisionConflict }
+
+// Code returns errC.PreconditionFailed or errC.Conflict.
+func (e *RevisionConflictError) Code() string {
+	if e.Precondition {
+		return errC.PreconditionFailed
+	}
+	return errC.Conflict
+}
+
+// HTTPError converts the error into a 412 Precondition Failed or 409 Conflict
+// response carrying the current revision in ReferenceID.
+// Locale is optional; defaults to "en".
+func (e *RevisionConflictError) HTTPError(requestID string, locale ...string) *httperror.HTTPError {
+	var he *httperror.HTTPError
+	if e.Precondition {
+		he = httperror.PreconditionFailed(requestID, "", locale...)
+	} else {
+		he = httperror.Conflict(requestID, "", locale...)
+	}
+	return he.WithReferenceID(strconv.FormatInt(e.Current, 10)).WithCause(e)
+}
+
+// CheckRevision compares a client supplied revision (e.g. from an If-Match
+// header) with the entity's revision.
+//
+// Returns *RevisionConflictError with Precondition set on mismatch.
+func (c *CoreModel) CheckRevision(expected int64) error {
+	if c.Revision != expected {
+		return &RevisionConflictError{ID: c.ID, Expected: expected, Current: c.Revision, Precondition: true}
+	}
+	return nil
+}
+
+// guardRevision adds "revision = expected" to the update statement and bumps
+// the revision. Models with a zero revision (never loaded or created through
+// CoreModel) are updated without a check.
+func (b *CoreModel) guardRevision(tx *gorm.DB) {
+	if b.Revision <= 0 {
+		return
+	}
+	expected := b.Revision
+	tx.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "revision"}, Value: expected},
+	}})
+	tx.Statement.Settings.Store(revisionSettingKey, expected)
+	b.Revision = expected + 1
+	tx.Statement.SetColumn("revision", b.Revision, true)
+}
+
+// AfterUpdate is a GORM hook rejecting updates that matched no row because
+// the revision moved on. The in-memory revision is restored when no row
+// matched. A missing row is not a conflict, so db.Save can fall back to
+// inserting a new entity.
+func (b *CoreModel) AfterUpdate(tx *gorm.DB) error {
+	v, ok := tx.Statement.Settings.Load(revisionSettingKey)
+	// Hooks run on a new session; the update's result lives on Statement.DB.
+	if !ok || tx.DryRun || tx.Statement.DB.RowsAffected > 0 {
+		return nil
+	}
+	expected := v.(int64)
+	b.Revision = expected
+
+	var current int64
+	res := tx.Session(&gorm.Session{NewDB: true}).Unscoped().
+		Table(tx.Statement.Table).
+		Select("revision").
+		Where("id = ?", b.ID).
+		Scan(&current)
+	if res.Error == nil && res.RowsAffected == 0 {
+		return nil
+	}
+	return &RevisionConflictError{ID: b.ID, Expected: expected, Current: current}
+}
@@ -0,0 +1,216 @@
+package core_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+// staleConnPool behaves like a database where every UPDATE matches no row.
+type staleConnPool struct{}
+
+func (staleConnPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (staleConnPool) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+func (staleConnPool) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, errors.New("not supported")
+}
+func (staleConnPool) QueryRowContext(context.Context, string, ...any) *sql.Row { return nil }
+
+// freshConnPool behaves like a database where every UPDATE matches one row.
+type freshConnPool struct{}
+
+func (freshConnPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (freshConnPool) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+func (freshConnPool) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, errors.New("not supported")
+}
+func (freshConnPool) QueryRowContext(context.Context, string, ...any) *sql.Row { return nil }
+
+// revisionConn is a database where UPDATEs match no row, INSERTs succeed
+// and queries return the revision in current, or no row when it is nil.
+type revisionConn struct {
+	stmts   *[]string
+	current *int64
+}
+
+func (c revisionConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c revisionConn) Driver() driver.Driver                        { return nil }
+func (c revisionConn) Prepare(query string) (driver.Stmt, error) {
+	*c.stmts = append(*c.stmts, query)
+	return revisionStmt{c, query}, nil
+}
+func (c revisionConn) Close() error              { return nil }
+func (c revisionConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+type revisionStmt struct {
+	conn  revisionConn
+	query string
+}
+
+func (s revisionStmt) Close() error  { return nil }
+func (s revisionStmt) NumInput() int { return -1 }
+func (s revisionStmt) Exec([]driver.Value) (driver.Result, error) {
+	if strings.HasPrefix(s.query, "INSERT") {
+		return driver.RowsAffected(1), nil
+	}
+	return driver.RowsAffected(0), nil
+}
+func (s revisionStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &revisionRows{current: s.conn.current}, nil
+}
+
+type revisionRows struct {
+	current *int64
+	done    bool
+}
+
+func (r *revisionRows) Columns() []string { return []string{"revision"} }
+func (r *revisionRows) Close() error      { return nil }
+func (r *revisionRows) Next(dest []driver.Value) error {
+	if r.current == nil || r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = *r.current
+	return nil
+}
+
+func revisionDB(t *testing.T, current *int64) (*gorm.DB, *[]string) {
+	t.Helper()
+	var stmts []string
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{
+		ConnPool:               sql.OpenDB(revisionConn{stmts: &stmts, current: current}),
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	return db, &stmts
+}
+
+func TestCoreModel_Create_sets_revision(t *testing.T) {
+	c := core.CoreModel{}
+	c.Create("user@domain.com", "grasp-labs", ids.New[ids.Tenant]())
+	assert.Equal(t, int64(1), c.Revision)
+}
+
+func TestCoreModel_CheckRevision(t *testing.T) {
+	c := core.CoreModel{ID: ids.New[ids.Entity](), Revision: 3}
+	assert.NoError(t, c.CheckRevision(3))
+
+	err := c.CheckRevision(2)
+	assert.True(t, errors.Is(err, core.ErrRevisionConflict))
+
+	var rc *core.RevisionConflictError
+	assert.True(t, errors.As(err, &rc))
+	assert.Equal(t, errC.PreconditionFailed, rc.Code())
+
+	httpErr := rc.HTTPError("31ac4e2a-10a1-471d-ac7c-fd6ee13a526d")
+	assert.Equal(t, http.StatusPreconditionFailed, httpErr.Status())
+	assert.Equal(t, "3", httpErr.ReferenceID)
+}
+
+func TestRevision_update_is_guarded(t *testing.T) {
+	db := dryRunDB(t)
+	id := ids.MustParse[ids.Entity]("25948ccc-cf16-491e-9cd4-44d5ebb7bc54")
+
+	d := &dataset{CoreModel: core.CoreModel{ID: id, Revision: 4}}
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(d).Updates(map[string]any{"name": "new"})
+	})
+	assert.Contains(t, sql, "`revision`=5")
+	assert.Contains(t, sql, "`datasets`.`revision` = 4")
+	assert.Equal(t, int64(5), d.Revision)
+
+	// Zero revision: no guard.
+	d = &dataset{CoreModel: core.CoreModel{ID: id}}
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(d).Updates(map[string]any{"name": "new"})
+	})
+	assert.NotContains(t, sql, "revision")
+}
+
+func TestRevision_stale_update_conflicts(t *testing.T) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{
+		ConnPool:               staleConnPool{},
+		SkipDefaultTransaction: true,
+	})
+	assert.NoError(t, err)
+
+	d := &dataset{CoreModel: core.CoreModel{ID: ids.New[ids.Entity](), Name: "x", Revision: 2}}
+	err = db.Save(d).Error
+
+	var rc *core.RevisionConflictError
+	assert.True(t, errors.As(err, &rc))
+	assert.Equal(t, int64(2), rc.Expected)
+	assert.False(t, rc.Precondition)
+	assert.Equal(t, errC.Conflict, rc.Code())
+	assert.Equal(t, http.StatusConflict, rc.HTTPError("").Status())
+
+	// In-memory revision is restored so the caller can reload and retry.
+	assert.Equal(t, int64(2), d.Revision)
+}
+
+func TestRevision_matching_update_succeeds(t *testing.T) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{
+		ConnPool:               freshConnPool{},
+		SkipDefaultTransaction: true,
+	})
+	assert.NoError(t, err)
+
+	d := &dataset{CoreModel: core.CoreModel{ID: ids.New[ids.Entity](), Revision: 2}}
+	assert.NoError(t, db.Model(d).Updates(map[string]any{"name": "new"}).Error)
+	assert.Equal(t, int64(3), d.Revision)
+}
+
+func TestRevision_save_inserts_new_entity(t *testing.T) {
+	db, stmts := revisionDB(t, nil)
+
+	d := &dataset{CoreModel: core.CoreModel{Name: "orders"}}
+	d.Create("user@domain.com", "grasp-labs", ids.New[ids.Tenant]())
+	assert.NoError(t, db.Save(d).Error)
+	if assert.Len(t, *stmts, 3) {
+		assert.True(t, strings.HasPrefix((*stmts)[0], "UPDATE"))
+		assert.True(t, strings.HasPrefix((*stmts)[1], "SELECT"))
+		assert.True(t, strings.HasPrefix((*stmts)[2], "INSERT"))
+	}
+	assert.Equal(t, int64(1), d.Revision)
+}
+
+func TestRevision_save_conflicts_with_existing_row(t *testing.T) {
+	current := int64(3)
+	db, stmts := revisionDB(t, &current)
+
+	d := &dataset{CoreModel: core.CoreModel{Name: "orders"}}
+	d.Create("user@domain.com", "grasp-labs", ids.New[ids.Tenant]())
+	err := db.Save(d).Error
+
+	var rc *core.RevisionConflictError
+	if assert.True(t, errors.As(err, &rc)) {
+		assert.Equal(t, int64(1), rc.Expected)
+		assert.Equal(t, int64(3), rc.Current)
+	}
+	assert.Len(t, *stmts, 2)
+	assert.Equal(t, int64(1), d.Revision)
+}
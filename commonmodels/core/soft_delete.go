@@ -0,0 +1,195 @@
+package core
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	status "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+)
+
+// DeletedAt marks a row as soft deleted.
+//
+// It behaves like gorm.DeletedAt: queries on models embedding CoreModel
+// exclude rows with a deletion timestamp, and db.Delete turns into an UPDATE.
+// In addition, the UPDATE issued by db.Delete also sets status to
+// status.Deleted so the Status column never disagrees with DeletedAt.
+//
+// Use db.Unscoped() (or the WithDeleted scope) to see deleted rows and to
+// issue a hard DELETE.
+type DeletedAt gorm.DeletedAt
+
+// Value implements driver.Valuer.
+func (d DeletedAt) Value() (driver.Value, error) { return gorm.DeletedAt(d).Value() }
+
+// Scan implements sql.Scanner.
+func (d *DeletedAt) Scan(src any) error { return (*gorm.DeletedAt)(d).Scan(src) }
+
+// MarshalJSON encodes null when the row is not deleted.
+func (d DeletedAt) MarshalJSON() ([]byte, error) { return gorm.DeletedAt(d).MarshalJSON() }
+
+// UnmarshalJSON accepts null or an RFC 3339 timestamp.
+func (d *DeletedAt) UnmarshalJSON(b []byte) error { return (*gorm.DeletedAt)(d).UnmarshalJSON(b) }
+
+// QueryClauses hides soft deleted rows from queries.
+func (d DeletedAt) QueryClauses(f *schema.Field) []clause.Interface {
+	return gorm.DeletedAt(d).QueryClauses(f)
+}
+
+// UpdateClauses hides soft deleted rows from updates.
+func (d DeletedAt) UpdateClauses(f *schema.Field) []clause.Interface {
+	return gorm.DeletedAt(d).UpdateClauses(f)
+}
+
+// DeleteClauses turns DELETE into an UPDATE of deleted_at and status that
+// also stamps modified_at and bumps revision.
+func (DeletedAt) DeleteClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{softDeleteClause{Field: f}}
+}
+
+// softDeleteClause mirrors gorm.SoftDeleteDeleteClause, additionally setting
+// the status and modified_at columns and bumping revision, so clients holding
+// the previous revision cannot update the deleted row.
+type softDeleteClause struct {
+	Field *schema.Field
+}
+
+func (sd softDeleteClause) Name() string               { return "" }
+func (sd softDeleteClause) Build(clause.Builder)       {}
+func (sd softDeleteClause) MergeClause(*clause.Clause) {}
+
+func (sd softDeleteClause) ModifyStatement(stmt *gorm.Statement) {
+	if stmt.SQL.Len() != 0 || stmt.Unscoped {
+		return
+	}
+	now := nowFrom(stmt.Context)
+	set := clause.Set{
+		{Column: clause.Column{Name: sd.Field.DBName}, Value: now},
+		{Column: clause.Column{Name: "status"}, Value: status.Deleted},
+		{Column: clause.Column{Name: "modified_at"}, Value: now},
+		{Column: clause.Column{Name: "revision"}, Value: gorm.Expr("revision + 1")},
+	}
+	stmt.SetColumn(sd.Field.DBName, now, true)
+	stmt.SetColumn("status", status.Deleted, true)
+	stmt.SetColumn("modified_at", now, true)
+	if e, ok := stmt.Model.(Entity); ok && e.Core().Revision > 0 {
+		e.Core().Revision++
+	}
+	if subject, ok := SubjectFromContext(stmt.Context); ok {
+		set = append(set,
+			clause.Assignment{Column: clause.Column{Name: "deleted_by"}, Value: subject},
+			clause.Assignment{Column: clause.Column{Name: "modified_by"}, Value: subject},
+		)
+	} else if e, ok := stmt.Model.(Entity); ok && e.Core().DeletedBy != "" {
+		set = append(set, clause.Assignment{Column: clause.Column{Name: "deleted_by"}, Value: e.Core().DeletedBy})
+	}
+	stmt.AddClause(set)
+
+	if stmt.Schema != nil {
+		_, queryValues := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmt.Schema.PrimaryFields)
+		column, values := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+		if len(values) > 0 {
+			stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.IN{Column: column, Values: values}}})
+		}
+	}
+
+	gorm.SoftDeleteQueryClause{Field: sd.Field}.ModifyStatement(stmt)
+	stmt.AddClauseIfNotExists(clause.Update{})
+	stmt.Build(stmt.DB.Callback().Update().Clauses...)
+}
+
+// IsDeleted reports whether the entity is soft deleted.
+func (c *CoreModel) IsDeleted() bool {
+	return c.DeletedAt.Valid
+}
+
+// Delete soft deletes the entity in memory: sets Status to status.Deleted,
+// stamps DeletedAt/DeletedBy and calls Touch(subject).
+//
+// Persist with db.Save; the row is then hidden from default queries.
+func (c *CoreModel) Delete(subject string) {
+	now := c.now()
+	if c.Status != status.Deleted {
+		c.recordStatusChange(subject, c.Status, status.Deleted, "", "")
+	}
+	c.Status = status.Deleted
+	c.DeletedAt = DeletedAt{Time: now, Valid: true}
+	c.DeletedBy = subject
+	c.Touch(subject)
+}
+
+// Restore undoes a soft delete of e, moving it to the given status
+// (typically status.Draft or status.Active). The status must be part of the
+// table registered for the entity type (see RegisterTransitions).
+//
+// Returns *TransitionError if e is not deleted or the status is not a valid
+// restore target. Persist with db.Unscoped().Save since the row is hidden
+// from scoped statements.
+func Restore(e Entity, subject string, to status.Status) error {
+	return e.Core().RestoreWith(TransitionsFor(e), subject, to)
+}
+
+// Restore is like the package level Restore using DefaultTransitions.
+//
+// Deprecated: on an entity embedding CoreModel this ignores the table
+// registered with RegisterTransitions. Use Restore(e, subject, to).
+func (c *CoreModel) Restore(subject string, to status.Status) error {
+	return c.RestoreWith(DefaultTransitions, subject, to)
+}
+
+// RestoreWith is like Restore but checks the status against the given
+// table. status.Deleted and statuses that are not in status.ValidStatus or
+// the table are rejected.
+func (c *CoreModel) RestoreWith(table TransitionTable, subject string, to status.Status) error {
+	if !c.IsDeleted() && c.Status != status.Deleted {
+		return &TransitionError{From: c.Status, To: to}
+	}
+	if _, ok := status.ValidStatus[to]; !ok || to == status.Deleted || !table.includes(to) {
+		return &TransitionError{From: c.Status, To: to}
+	}
+	c.recordStatusChange(subject, c.Status, to, "", "")
+	c.Status = to
+	c.DeletedAt = DeletedAt{}
+	c.DeletedBy = ""
+	c.Touch(subject)
+	return nil
+}
+
+// syncDeletion keeps Status and DeletedAt consistent before writes.
+func (c *CoreModel) syncDeletion(now time.Time) {
+	switch {
+	case c.Status == status.Deleted && !c.DeletedAt.Valid:
+		c.DeletedAt = DeletedAt{Time: now, Valid: true}
+		if c.DeletedBy == "" {
+			c.DeletedBy = c.ModifiedBy
+		}
+	case c.Status != status.Deleted && c.DeletedAt.Valid:
+		c.DeletedAt = DeletedAt{}
+		c.DeletedBy = ""
+	}
+}
+
+// WithDeleted is a GORM scope including soft deleted rows.
+//
+//	db.Scopes(core.WithDeleted).Find(&datasets)
+func WithDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// OnlyDeleted is a GORM scope returning soft deleted rows only.
+//
+//	db.Scopes(core.OnlyDeleted).Find(&datasets)
+func OnlyDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Where(clause.Neq{
+		Column: clause.Column{Table: clause.CurrentTable, Name: "deleted_at"},
+		Value:  nil,
+	})
+}
+
+// Purge permanently removes the entity's row, bypassing soft delete.
+func Purge(db *gorm.DB, e Entity) error {
+	return db.Unscoped().Delete(e).Error
+}
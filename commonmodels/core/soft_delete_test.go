@@ -0,0 +1,157 @@
+package core_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+func TestCoreModel_Delete_and_Restore(t *testing.T) {
+	fixed := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	t.Parallel()
+
+	c := core.CoreModel{Name: "x", Status: status.Active}
+	c.UseClock(clock.NewFake(fixed))
+	c.Create("creator@domain.com", "grasp-labs", ids.New[ids.Tenant]())
+
+	c.Delete("user@domain.com")
+	assert.True(t, c.IsDeleted())
+	assert.Equal(t, status.Deleted, c.Status)
+	assert.Equal(t, fixed, c.DeletedAt.Time)
+	assert.Equal(t, "user@domain.com", c.DeletedBy)
+	assert.Equal(t, "user@domain.com", c.ModifiedBy)
+	assert.Empty(t, c.Validate())
+
+	assert.NoError(t, c.Restore("admin@domain.com", status.Draft))
+	assert.False(t, c.IsDeleted())
+	assert.Equal(t, status.Draft, c.Status)
+	assert.Empty(t, c.DeletedBy)
+	assert.Equal(t, "admin@domain.com", c.ModifiedBy)
+
+	err := c.Restore("admin@domain.com", status.Active)
+	assert.True(t, errors.Is(err, core.ErrInvalidTransition))
+}
+
+func TestCoreModel_Restore_rejects_invalid_targets(t *testing.T) {
+	for _, to := range []status.Status{"bogus", status.Deleted, ""} {
+		c := core.CoreModel{Name: "x", Status: status.Active}
+		c.Delete("user@domain.com")
+
+		err := c.Restore("admin@domain.com", to)
+		assert.ErrorIs(t, err, core.ErrInvalidTransition, "restore to %q", to)
+		assert.Equal(t, status.Deleted, c.Status)
+		assert.True(t, c.IsDeleted())
+	}
+}
+
+// job has a registered table without status.Suspended.
+type job struct {
+	core.CoreModel
+}
+
+func TestRestore_registeredTable(t *testing.T) {
+	core.RegisterTransitions(&job{}, core.TransitionTable{
+		status.Draft:  {status.Active, status.Deleted},
+		status.Active: {status.Deleted},
+	})
+
+	j := &job{CoreModel: core.CoreModel{Name: "x", Status: status.Active}}
+	j.Delete("user@domain.com")
+
+	assert.ErrorIs(t, core.Restore(j, "admin@domain.com", status.Suspended), core.ErrInvalidTransition)
+	assert.True(t, j.IsDeleted())
+
+	assert.NoError(t, core.Restore(j, "admin@domain.com", status.Active))
+	assert.False(t, j.IsDeleted())
+	assert.Equal(t, status.Active, j.Status)
+}
+
+func TestCoreModel_DeletedAt_JSON(t *testing.T) {
+	c := core.CoreModel{}
+	b, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"deleted_at":null`)
+	assert.NotContains(t, string(b), `"deleted_by"`)
+
+	c.Delete("user@domain.com")
+	b, err = json.Marshal(c)
+	assert.NoError(t, err)
+
+	var out core.CoreModel
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.True(t, out.IsDeleted())
+	assert.Equal(t, "user@domain.com", out.DeletedBy)
+}
+
+func TestSoftDelete_queries_hide_deleted_rows(t *testing.T) {
+	db := dryRunDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var rows []dataset
+		return tx.Find(&rows)
+	})
+	assert.Contains(t, sql, "`datasets`.`deleted_at` IS NULL")
+
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var rows []dataset
+		return tx.Scopes(core.WithDeleted).Find(&rows)
+	})
+	assert.NotContains(t, sql, "deleted_at")
+
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var rows []dataset
+		return tx.Scopes(core.OnlyDeleted).Find(&rows)
+	})
+	assert.Contains(t, sql, "`datasets`.`deleted_at` IS NOT NULL")
+}
+
+func TestSoftDelete_delete_sets_status(t *testing.T) {
+	db := dryRunDB(t)
+	id := ids.MustParse[ids.Entity]("25948ccc-cf16-491e-9cd4-44d5ebb7bc54")
+
+	d := &dataset{CoreModel: core.CoreModel{ID: id, DeletedBy: "user@domain.com", Revision: 3}}
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Delete(d)
+	})
+	assert.Contains(t, sql, "UPDATE `datasets` SET `deleted_at`=")
+	assert.Contains(t, sql, "`status`=\"deleted\"")
+	assert.Contains(t, sql, "`modified_at`=")
+	assert.Contains(t, sql, "`revision`=revision + 1")
+	assert.Equal(t, int64(4), d.Revision)
+	assert.False(t, d.ModifiedAt.IsZero())
+	assert.Contains(t, sql, "`deleted_by`=\"user@domain.com\"")
+	assert.Contains(t, sql, "`deleted_at` IS NULL")
+}
+
+func TestSoftDelete_purge_is_hard_delete(t *testing.T) {
+	db := dryRunDB(t)
+	id := ids.MustParse[ids.Entity]("25948ccc-cf16-491e-9cd4-44d5ebb7bc54")
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Unscoped().Delete(&dataset{CoreModel: core.CoreModel{ID: id}})
+	})
+	assert.Contains(t, sql, "DELETE FROM `datasets`")
+
+	assert.NoError(t, core.Purge(db, &dataset{CoreModel: core.CoreModel{ID: id}}))
+}
+
+func TestBeforeUpdate_syncs_deletion(t *testing.T) {
+	d := &dataset{CoreModel: core.CoreModel{Status: status.Deleted, ModifiedBy: "user@domain.com"}}
+	assert.NoError(t, d.BeforeUpdate(dryRunDB(t)))
+	assert.True(t, d.IsDeleted())
+	assert.Equal(t, "user@domain.com", d.DeletedBy)
+
+	d.Status = status.Active
+	assert.NoError(t, d.BeforeUpdate(dryRunDB(t)))
+	assert.False(t, d.IsDeleted())
+	assert.Empty(t, d.DeletedBy)
+}
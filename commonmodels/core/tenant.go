@@ -0,0 +1,162 @@
+package core
+
+import (
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+var (
+	// ErrTenantRequired is returned when a tenant scoped statement runs
+	// without a tenant in its context (see WithTenant).
+	ErrTenantRequired = errors.New("tenant required in context")
+	// ErrTenantMismatch is returned when a write targets another tenant than
+	// the one in the statement's context.
+	ErrTenantMismatch = errors.New("tenant does not match context tenant")
+)
+
+var entityType = reflect.TypeOf((*Entity)(nil)).Elem()
+
+// TenantPlugin is a GORM plugin enforcing tenant isolation on models that
+// embed CoreModel.
+//
+// For every statement on such a model it reads the tenant from the
+// statement's context (see WithTenant) and:
+//   - adds "tenant_id = ?" to queries, row queries, updates and deletes;
+//   - fills a missing TenantID on create and rejects creates for another tenant;
+//   - rejects updates whose model or values carry another TenantID.
+//
+// Statements without a tenant fail with ErrTenantRequired. Use
+// WithoutTenantScope for admin jobs. Raw SQL (db.Raw/db.Exec) is not
+// inspected.
+//
+//	db.Use(core.TenantPlugin{})
+type TenantPlugin struct{}
+
+// Name implements gorm.Plugin.
+func (TenantPlugin) Name() string { return "core:tenant" }
+
+// Initialize implements gorm.Plugin.
+func (TenantPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:before_create").Register("core:tenant_create", tenantCreate); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("core:tenant_query", tenantFilter); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("core:tenant_row", tenantFilter); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("core:tenant_update", tenantUpdate); err != nil {
+		return err
+	}
+	return cb.Delete().Before("gorm:delete").Register("core:tenant_delete", tenantFilter)
+}
+
+// tenantScope returns the context tenant for statements on CoreModel
+// entities. ok is false when the statement is not subject to tenant scoping.
+func tenantScope(db *gorm.DB) (tenantID ids.TenantID, ok bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return ids.TenantID{}, false
+	}
+	if !reflect.PointerTo(db.Statement.Schema.ModelType).Implements(entityType) {
+		return ids.TenantID{}, false
+	}
+	if tenantScopeSkipped(db.Statement.Context) {
+		return ids.TenantID{}, false
+	}
+	tenantID, found := TenantFromContext(db.Statement.Context)
+	if !found {
+		_ = db.AddError(ErrTenantRequired)
+		return ids.TenantID{}, false
+	}
+	return tenantID, true
+}
+
+func tenantFilter(db *gorm.DB) {
+	tenantID, ok := tenantScope(db)
+	if !ok {
+		return
+	}
+	column := "tenant_id"
+	if f := db.Statement.Schema.LookUpField("TenantID"); f != nil {
+		column = f.DBName
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: column}, Value: tenantID},
+	}})
+}
+
+func tenantCreate(db *gorm.DB) {
+	tenantID, ok := tenantScope(db)
+	if !ok {
+		return
+	}
+	eachEntity(db.Statement.ReflectValue, func(c *CoreModel) {
+		switch c.TenantID {
+		case ids.TenantID{}:
+			c.TenantID = tenantID
+		case tenantID:
+		default:
+			_ = db.AddError(ErrTenantMismatch)
+		}
+	})
+}
+
+func tenantUpdate(db *gorm.DB) {
+	tenantID, ok := tenantScope(db)
+	if !ok {
+		return
+	}
+	check := func(c *CoreModel) {
+		if !c.TenantID.IsZero() && c.TenantID != tenantID {
+			_ = db.AddError(ErrTenantMismatch)
+		}
+	}
+	eachEntity(db.Statement.ReflectValue, check)
+	if db.Statement.Dest != db.Statement.Model {
+		switch dest := db.Statement.Dest.(type) {
+		case map[string]any:
+			for _, key := range []string{"tenant_id", "TenantID"} {
+				if v, found := dest[key]; found && v != tenantID && v != tenantID.UUID() && v != tenantID.String() {
+					_ = db.AddError(ErrTenantMismatch)
+				}
+			}
+		default:
+			eachEntity(reflect.ValueOf(dest), check)
+		}
+	}
+	tenantFilter(db)
+}
+
+// eachEntity calls fn for every CoreModel entity in v. Structs that cannot be
+// addressed, such as values passed to db.Updates, are copied first, so fn
+// sees the copy and changes it makes are discarded.
+func eachEntity(v reflect.Value, fn func(*CoreModel)) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			eachEntity(v.Index(i), fn)
+		}
+	case reflect.Struct:
+		if !v.CanAddr() {
+			cp := reflect.New(v.Type()).Elem()
+			cp.Set(v)
+			v = cp
+		}
+		if e, ok := v.Addr().Interface().(Entity); ok {
+			fn(e.Core())
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+var tenantA = ids.MustParse[ids.Tenant]("25948ccc-cf16-491e-9cd4-44d5ebb7bc54")
+var tenantB = ids.MustParse[ids.Tenant]("31ac4e2a-10a1-471d-ac7c-fd6ee13a526d")
+
+func tenantDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := dryRunDB(t)
+	if err := db.Use(core.TenantPlugin{}); err != nil {
+		t.Fatalf("failed to register plugin: %v", err)
+	}
+	return db
+}
+
+func TestTenantPlugin_filters_queries(t *testing.T) {
+	db := tenantDB(t)
+	ctx := core.WithTenant(context.Background(), tenantA)
+
+	var rows []dataset
+	tx := db.WithContext(ctx).Where("name = ?", "x").Find(&rows)
+	assert.NoError(t, tx.Error)
+	sql := tx.Statement.SQL.String()
+	assert.Contains(t, sql, "`datasets`.`tenant_id` = ?")
+	assert.Contains(t, tx.Statement.Vars, tenantA)
+}
+
+func TestTenantPlugin_requires_tenant(t *testing.T) {
+	db := tenantDB(t)
+
+	var rows []dataset
+	err := db.WithContext(context.Background()).Find(&rows).Error
+	assert.True(t, errors.Is(err, core.ErrTenantRequired))
+}
+
+func TestTenantPlugin_escape_hatch(t *testing.T) {
+	db := tenantDB(t)
+	ctx := core.WithoutTenantScope(context.Background())
+
+	var rows []dataset
+	tx := db.WithContext(ctx).Find(&rows)
+	assert.NoError(t, tx.Error)
+	assert.NotContains(t, tx.Statement.SQL.String(), "tenant_id")
+}
+
+func TestTenantPlugin_ignores_other_models(t *testing.T) {
+	type plain struct {
+		ID   int
+		Name string
+	}
+	db := tenantDB(t)
+
+	var rows []plain
+	tx := db.Find(&rows)
+	assert.NoError(t, tx.Error)
+	assert.NotContains(t, tx.Statement.SQL.String(), "tenant_id")
+}
+
+func TestTenantPlugin_create(t *testing.T) {
+	db := tenantDB(t)
+	ctx := core.WithTenant(context.Background(), tenantA)
+
+	d := &dataset{CoreModel: core.CoreModel{Name: "x"}}
+	assert.NoError(t, db.WithContext(ctx).Create(d).Error)
+	assert.Equal(t, tenantA, d.TenantID)
+	assert.Equal(t, tenantA.String(), d.Tags.Data["tenant_id"])
+
+	other := []dataset{
+		{CoreModel: core.CoreModel{Name: "ok", TenantID: tenantA}},
+		{CoreModel: core.CoreModel{Name: "leak", TenantID: tenantB}},
+	}
+	err := db.WithContext(ctx).Create(&other).Error
+	assert.True(t, errors.Is(err, core.ErrTenantMismatch))
+}
+
+func TestTenantPlugin_update_and_delete(t *testing.T) {
+	db := tenantDB(t)
+	ctx := core.WithTenant(context.Background(), tenantA)
+	id := ids.New[ids.Entity]()
+
+	tx := db.WithContext(ctx).Model(&dataset{CoreModel: core.CoreModel{ID: id}}).Update("name", "y")
+	assert.NoError(t, tx.Error)
+	assert.Contains(t, tx.Statement.SQL.String(), "`datasets`.`tenant_id` = ?")
+
+	err := db.WithContext(ctx).Model(&dataset{CoreModel: core.CoreModel{ID: id, TenantID: tenantB}}).Update("name", "y").Error
+	assert.True(t, errors.Is(err, core.ErrTenantMismatch))
+
+	err = db.WithContext(ctx).Model(&dataset{CoreModel: core.CoreModel{ID: id}}).Updates(map[string]any{"tenant_id": tenantB}).Error
+	assert.True(t, errors.Is(err, core.ErrTenantMismatch))
+
+	err = db.WithContext(ctx).Model(&dataset{CoreModel: core.CoreModel{ID: id}}).Updates(dataset{CoreModel: core.CoreModel{TenantID: tenantB}}).Error
+	assert.True(t, errors.Is(err, core.ErrTenantMismatch))
+
+	tx = db.WithContext(ctx).Model(&dataset{CoreModel: core.CoreModel{ID: id}}).Updates(dataset{CoreModel: core.CoreModel{Name: "z"}})
+	assert.NoError(t, tx.Error)
+
+	tx = db.WithContext(ctx).Delete(&dataset{CoreModel: core.CoreModel{ID: id}})
+	assert.NoError(t, tx.Error)
+	assert.Contains(t, tx.Statement.SQL.String(), "`datasets`.`tenant_id` = ?")
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	status "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+	httperror "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/http_error"
+)
+
+// ErrInvalidTransition is a sentinel error used to identify rejected status
+// transitions.
+//
+//	errors.Is(err, core.ErrInvalidTransition)
+//
+// The concrete error will be *TransitionError.
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// TransitionTable lists, per current status, the statuses an entity may move to.
+//
+// Example:
+//
+//	table := core.TransitionTable{
+//		status.Draft:  {status.Active, status.Deleted},
+//		status.Active: {status.Deleted},
+//	}
+type TransitionTable map[status.Status][]status.Status
+
+// Allows reports whether moving from one status to another is permitted.
+func (t TransitionTable) Allows(from, to status.Status) bool {
+	for _, s := range t[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// includes reports whether s is a status of the lifecycle t describes,
+// either as a current status or as a target.
+func (t TransitionTable) includes(s status.Status) bool {
+	if _, ok := t[s]; ok {
+		return true
+	}
+	for _, targets := range t {
+		for _, to := range targets {
+			if to == s {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DefaultTransitions is the lifecycle used when no per-entity table is registered.
+//
+//   - draft     -> active, rejected, deleted
+//   - active    -> inactive, suspended, closed, deleted
+//   - inactive  -> active, deleted
+//   - suspended -> active, deleted
+//   - rejected  -> draft, deleted
+//   - closed    -> deleted
+var DefaultTransitions = TransitionTable{
+	status.Draft:     {status.Active, status.Rejected, status.Deleted},
+	status.Active:    {status.Inactive, status.Suspended, status.Closed, status.Deleted},
+	status.Inactive:  {status.Active, status.Deleted},
+	status.Suspended: {status.Active, status.Deleted},
+	status.Rejected:  {status.Draft, status.Deleted},
+	status.Closed:    {status.Deleted},
+}
+
+var (
+	transitionsMu sync.RWMutex
+	transitions   = map[reflect.Type]TransitionTable{}
+)
+
+// RegisterTransitions installs the transition table used for the entity type
+// of model. Register once at service start-up, e.g.:
+//
+//	core.RegisterTransitions(&Dataset{}, datasetTransitions)
+func RegisterTransitions(model Entity, table TransitionTable) {
+	transitionsMu.Lock()
+	defer transitionsMu.Unlock()
+	transitions[reflect.TypeOf(model)] = table
+}
+
+// TransitionsFor returns the table registered for the entity type of model,
+// falling back to DefaultTransitions.
+func TransitionsFor(model Entity) TransitionTable {
+	transitionsMu.RLock()
+	defer transitionsMu.RUnlock()
+	if t, ok := transitions[reflect.TypeOf(model)]; ok {
+		return t
+	}
+	return DefaultTransitions
+}
+
+// TransitionError reports a status change that is not allowed by the
+// transition table in use. It maps to errC.Conflict.
+type TransitionError struct {
+	From status.Status
+	To   status.Status
+}
+
+// Error implements the error interface.
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: %q -> %q", ErrInvalidTransition, e.From, e.To)
+}
+
+// Is allows errors.Is(err, ErrInvalidTransition).
+func (e *TransitionError) Is(target error) bool { return target == ErrInvalidTransition }
+
+// Code returns the machine-readable error code.
+func (e *TransitionError) Code() string { return errC.Conflict }
+
+// HTTPError converts the error into a 409 Conflict response.
+// Locale is optional; defaults to "en".
+func (e *TransitionError) HTTPError(requestID string, locale ...string) *httperror.HTTPError {
+	return httperror.Conflict(requestID, "", locale...).WithCause(e)
+}
+
+// TransitionTo moves the entity to a new status using DefaultTransitions and
+// records the change via Touch(subject).
+//
+// Returns *TransitionError if the move is not allowed; the model is left
+// unchanged in that case.
+//
+// Deprecated: on an entity embedding CoreModel this ignores the table
+// registered with RegisterTransitions. Use Transition(e, subject, to).
+func (c *CoreModel) TransitionTo(subject string, to status.Status) error {
+	return c.TransitionWith(DefaultTransitions, subject, to)
+}
+
+// TransitionWith is like TransitionTo but validates against the given table.
+func (c *CoreModel) TransitionWith(table TransitionTable, subject string, to status.Status) error {
+	return c.transition(table, subject, to, "", "")
+}
+
+// TransitionToWithReason is like TransitionTo and records a machine-readable
+// reason code and a free-text reason in the status history (see
+// StatusChange), e.g. when suspending or rejecting an entity.
+//
+// Deprecated: like TransitionTo this ignores registered tables. Use
+// TransitionWithReason(e, subject, to, code, reason).
+func (c *CoreModel) TransitionToWithReason(subject string, to status.Status, code, reason string) error {
+	return c.transition(DefaultTransitions, subject, to, code, reason)
+}
+
+func (c *CoreModel) transition(table TransitionTable, subject string, to status.Status, code, reason string) error {
+	if !table.Allows(c.Status, to) {
+		return &TransitionError{From: c.Status, To: to}
+	}
+	c.recordStatusChange(subject, c.Status, to, code, reason)
+	c.Status = to
+	c.Touch(subject)
+	return nil
+}
+
+// Transition moves e to a new status using the table registered for its type
+// (see RegisterTransitions). This is the supported way to change the status
+// of entities embedding CoreModel:
+//
+//	err := core.Transition(ds, subject, status.Active)
+func Transition(e Entity, subject string, to status.Status) error {
+	return e.Core().TransitionWith(TransitionsFor(e), subject, to)
+}
+
+// TransitionWithReason is like Transition and records the reason in the
+// status history.
+func TransitionWithReason(e Entity, subject string, to status.Status, code, reason string) error {
+	return e.Core().transition(TransitionsFor(e), subject, to, code, reason)
+}
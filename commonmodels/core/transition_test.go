@@ -0,0 +1,113 @@
+package core_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+type workflow struct {
+	core.CoreModel
+	Steps int `json:"steps"`
+}
+
+func TestCoreModel_TransitionTo_allowed(t *testing.T) {
+	fixed := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	t.Parallel()
+
+	c := core.CoreModel{Status: status.Draft}
+	c.UseClock(clock.NewFake(fixed))
+	c.Create("creator@domain.com", "grasp-labs", ids.New[ids.Tenant]())
+
+	err := c.TransitionTo("user@domain.com", status.Active)
+	assert.NoError(t, err)
+	assert.Equal(t, status.Active, c.Status)
+	assert.Equal(t, "user@domain.com", c.ModifiedBy)
+	assert.Equal(t, fixed, c.ModifiedAt)
+}
+
+func TestCoreModel_TransitionTo_rejected(t *testing.T) {
+	c := core.CoreModel{Status: status.Deleted, ModifiedBy: "creator@domain.com"}
+
+	err := c.TransitionTo("user@domain.com", status.Active)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, core.ErrInvalidTransition))
+
+	var te *core.TransitionError
+	assert.True(t, errors.As(err, &te))
+	assert.Equal(t, status.Deleted, te.From)
+	assert.Equal(t, status.Active, te.To)
+	assert.Equal(t, errC.Conflict, te.Code())
+
+	// Model is untouched on failure.
+	assert.Equal(t, status.Deleted, c.Status)
+	assert.Equal(t, "creator@domain.com", c.ModifiedBy)
+
+	httpErr := te.HTTPError("31ac4e2a-10a1-471d-ac7c-fd6ee13a526d")
+	assert.Equal(t, http.StatusConflict, httpErr.Status())
+	assert.Equal(t, errC.Conflict, httpErr.Code)
+	assert.True(t, errors.Is(httpErr, core.ErrInvalidTransition))
+}
+
+func TestDefaultTransitions_anyToDeleted(t *testing.T) {
+	for from := range core.DefaultTransitions {
+		assert.True(t, core.DefaultTransitions.Allows(from, status.Deleted), "expected %s -> deleted", from)
+	}
+}
+
+func TestTransition_registeredTable(t *testing.T) {
+	core.RegisterTransitions(&workflow{}, core.TransitionTable{
+		status.Draft: {status.Closed},
+	})
+
+	w := &workflow{CoreModel: core.CoreModel{Status: status.Draft}}
+	assert.NoError(t, core.Transition(w, "user@domain.com", status.Closed))
+	assert.Equal(t, status.Closed, w.Status)
+
+	// Draft -> active is allowed by default but not by the registered table.
+	w.Status = status.Draft
+	assert.ErrorIs(t, core.Transition(w, "user@domain.com", status.Active), core.ErrInvalidTransition)
+
+	// Types without a registered table fall back to DefaultTransitions.
+	c := &core.CoreModel{Status: status.Draft}
+	assert.NoError(t, core.Transition(c, "user@domain.com", status.Active))
+}
+
+func TestDefaultTransitions_targets_validate(t *testing.T) {
+	for from, targets := range core.DefaultTransitions {
+		for _, to := range targets {
+			c := core.CoreModel{Name: "x", Status: from}
+			c.Create("creator@domain.com", "grasp-labs", ids.New[ids.Tenant]())
+			assert.NoError(t, c.TransitionTo("user@domain.com", to))
+			assert.Empty(t, c.Validate(), "%s -> %s", from, to)
+		}
+	}
+}
+
+// ticket has a registered table that differs from DefaultTransitions.
+type ticket struct {
+	core.CoreModel
+}
+
+func TestTransition_registeredTable_through_entity(t *testing.T) {
+	core.RegisterTransitions(&ticket{}, core.TransitionTable{
+		status.Draft:     {status.Suspended},
+		status.Suspended: {status.Closed},
+	})
+
+	tk := &ticket{CoreModel: core.CoreModel{Status: status.Draft}}
+	assert.ErrorIs(t, core.Transition(tk, "user@domain.com", status.Active), core.ErrInvalidTransition)
+	assert.NoError(t, core.Transition(tk, "user@domain.com", status.Suspended))
+	assert.NoError(t, core.Transition(tk, "user@domain.com", status.Closed))
+	assert.Equal(t, status.Closed, tk.Status)
+	assert.Equal(t, "user@domain.com", tk.ModifiedBy)
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	verr "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
+)
+
+// Validity is an optional mixin for entities that are only valid within a
+// time window, such as grants, schedules or API keys. Embed it next to
+// CoreModel:
+//
+//	type APIKey struct {
+//		core.CoreModel
+//		core.Validity
+//	}
+//
+// The window is half-open, [ValidFrom, ValidTo); a nil bound is unbounded.
+type Validity struct {
+	ValidFrom *time.Time `gorm:"index" json:"valid_from,omitempty"`
+	ValidTo   *time.Time `gorm:"index" json:"valid_to,omitempty"`
+}
+
+// ValidateValidity checks that ValidFrom is before ValidTo when both are set.
+func (v *Validity) ValidateValidity(loc, locale string) []verr.ValidationError {
+	if locale == "" {
+		locale = "en"
+	}
+	if v.ValidFrom != nil && v.ValidTo != nil && !v.ValidFrom.Before(*v.ValidTo) {
+		msg := errC.HumanMessageLocale(locale, errC.InvalidRange, "valid_to", "valid_from")
+		return []verr.ValidationError{{Field: "valid_to", Message: msg, Loc: loc, Code: errC.InvalidRange}}
+	}
+	return nil
+}
+
+// IsEffective reports whether at falls within the window. A zero at means
+// Now().
+func (v *Validity) IsEffective(at time.Time) bool {
+	if at.IsZero() {
+		at = Now()
+	}
+	if v.ValidFrom != nil && at.Before(*v.ValidFrom) {
+		return false
+	}
+	if v.ValidTo != nil && !at.Before(*v.ValidTo) {
+		return false
+	}
+	return true
+}
+
+// IsEffectiveNow reports whether the window contains the current time of
+// the clock in ctx (see clock.WithClock), or Now() without one.
+func (v *Validity) IsEffectiveNow(ctx context.Context) bool {
+	return v.IsEffective(nowFrom(ctx))
+}
+
+// Expire ends the window at the given time (Now() when zero).
+func (v *Validity) Expire(at time.Time) {
+	if at.IsZero() {
+		at = Now()
+	}
+	v.ValidTo = &at
+}
+
+// ExpireNow ends the window at the current time of the clock in ctx, or
+// Now() without one.
+func (v *Validity) ExpireNow(ctx context.Context) {
+	v.Expire(nowFrom(ctx))
+}
+
+// EffectiveAt is a GORM scope returning rows whose window contains at.
+//
+//	db.Scopes(core.EffectiveAt(at)).Find(&keys)
+func EffectiveAt(at time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		from := clause.Column{Table: clause.CurrentTable, Name: "valid_from"}
+		to := clause.Column{Table: clause.CurrentTable, Name: "valid_to"}
+		return db.Where(clause.And(
+			clause.Or(clause.Eq{Column: from, Value: nil}, clause.Lte{Column: from, Value: at}),
+			clause.Or(clause.Eq{Column: to, Value: nil}, clause.Gt{Column: to, Value: at}),
+		))
+	}
+}
+
+// Effective is a GORM scope returning rows effective now, per the clock in
+// the statement context (see clock.WithClock) or Now.
+//
+//	db.Scopes(core.Effective).Find(&keys)
+func Effective(db *gorm.DB) *gorm.DB {
+	return EffectiveAt(nowFrom(db.Statement.Context))(db)
+}
@@ -0,0 +1,73 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+)
+
+// apiKey is an entity with a validity window.
+type apiKey struct {
+	core.CoreModel
+	core.Validity
+}
+
+func TestValidity_IsEffective(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	v := core.Validity{ValidFrom: &from, ValidTo: &to}
+
+	assert.False(t, v.IsEffective(from.Add(-time.Second)))
+	assert.True(t, v.IsEffective(from))
+	assert.True(t, v.IsEffective(to.Add(-time.Second)))
+	assert.False(t, v.IsEffective(to))
+	assert.True(t, (&core.Validity{}).IsEffective(time.Time{}))
+
+	v.Expire(from.Add(time.Minute))
+	assert.Equal(t, from.Add(time.Minute), *v.ValidTo)
+}
+
+func TestValidity_IsEffectiveNow(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	v := core.Validity{ValidFrom: &from}
+
+	ck := clock.NewFake(from.Add(time.Hour))
+	ctx := clock.WithClock(context.Background(), ck)
+	assert.True(t, v.IsEffectiveNow(ctx))
+
+	v.ExpireNow(ctx)
+	assert.Equal(t, from.Add(time.Hour), *v.ValidTo)
+	assert.False(t, v.IsEffectiveNow(ctx))
+	ck.Advance(-time.Minute)
+	assert.True(t, v.IsEffectiveNow(ctx))
+}
+
+func TestValidity_validate(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	k := apiKey{Validity: core.Validity{ValidFrom: &from, ValidTo: &from}}
+
+	errs := k.ValidateValidity("body", "en")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, errC.InvalidRange, errs[0].Code)
+	assert.Equal(t, "valid_to must be after valid_from.", errs[0].Message)
+
+	to := from.Add(time.Hour)
+	k.ValidTo = &to
+	assert.Empty(t, k.ValidateValidity("body", "en"))
+}
+
+func TestValidity_scopes(t *testing.T) {
+	at := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tx := dryRunDB(t).Scopes(core.EffectiveAt(at)).Find(&[]apiKey{})
+	assert.NoError(t, tx.Error)
+	assert.Contains(t, tx.Statement.SQL.String(),
+		"(`api_keys`.`valid_from` IS NULL OR `api_keys`.`valid_from` <= ?) AND (`api_keys`.`valid_to` IS NULL OR `api_keys`.`valid_to` > ?)")
+	assert.Equal(t, []any{at, at}, tx.Statement.Vars)
+}
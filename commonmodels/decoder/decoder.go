@@ -0,0 +1,261 @@
+// Package decoder decodes JSON request bodies into models, reporting bad
+// input as a validation_error.ErrorEnvelope instead of raw encoding/json
+// errors:
+//
+//	ds, err := decoder.Decode[Dataset](r.Body, "nb")
+//	if ve, ok := validation_error.Extract(err); ok {
+//		return c.JSON(http.StatusBadRequest, ve)
+//	}
+//
+// Unknown fields are rejected and the body is limited to DefaultMaxBytes
+// unless configured otherwise on a Decoder.
+package decoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	verr "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
+)
+
+// DefaultMaxBytes is the body size limit used when Decoder.MaxBytes is
+// not set.
+const DefaultMaxBytes int64 = 1 << 20
+
+const unknownFieldPrefix = "json: unknown field "
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// Decoder holds the decoding options. The zero value is ready to use.
+type Decoder struct {
+	// MaxBytes limits the body size; DefaultMaxBytes when <= 0.
+	MaxBytes int64
+	// AllowUnknownFields accepts fields the model does not declare.
+	AllowUnknownFields bool
+	// Locale of the error messages, "en" when empty.
+	Locale string
+}
+
+// Decode decodes the JSON body of r into a new T using a zero Decoder with
+// the given message locale.
+func Decode[T any](r io.Reader, locale string) (T, error) {
+	var v T
+	err := Decoder{Locale: locale}.Decode(r, &v)
+	return v, err
+}
+
+// Decode decodes a single JSON value from r into dst, which must be a
+// pointer.
+//
+// Invalid input is returned as *validation_error.ErrorEnvelope with Loc
+// "body" and one of the codes ContentTooLarge, Required (empty body),
+// InvalidJSONFormat, InvalidDataType or UnknownField. Errors reading r are
+// returned as is.
+func (d Decoder) Decode(r io.Reader, dst any) error {
+	limit := d.MaxBytes
+	if limit <= 0 {
+		limit = DefaultMaxBytes
+	}
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return d.envelope("", errC.ContentTooLarge)
+		}
+		return err
+	}
+	if int64(len(body)) > limit {
+		return d.envelope("", errC.ContentTooLarge)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return d.envelope("", errC.Required)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if !d.AllowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(dst); err != nil {
+		return d.translate(err, body, dst)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return d.envelope("", errC.InvalidJSONFormat)
+	}
+	return nil
+}
+
+// translate maps an encoding/json error to an envelope.
+func (d Decoder) translate(err error, body []byte, dst any) error {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return d.envelope("", errC.InvalidJSONFormat)
+	case errors.As(err, &typeErr):
+		var doc map[string]any
+		_ = json.Unmarshal(body, &doc)
+		return d.envelope(TypeErrorField(doc, reflect.TypeOf(dst).Elem(), typeErr), errC.InvalidDataType)
+	case strings.HasPrefix(err.Error(), unknownFieldPrefix):
+		name, uerr := strconv.Unquote(strings.TrimPrefix(err.Error(), unknownFieldPrefix))
+		if uerr != nil {
+			return err
+		}
+		var doc any
+		_ = json.Unmarshal(body, &doc)
+		if path, ok := unknownFieldPath(doc, reflect.TypeOf(dst).Elem(), name); ok {
+			name = path
+		}
+		return d.envelope(name, errC.UnknownField)
+	}
+	return err
+}
+
+func (d Decoder) envelope(field, code string) *verr.ErrorEnvelope {
+	locale := d.Locale
+	if locale == "" {
+		locale = "en"
+	}
+	var msg string
+	switch {
+	case code == errC.InvalidJSONFormat, code == errC.ContentTooLarge:
+		msg = errC.HumanMessageLocale(locale, code)
+	case field == "":
+		msg = errC.HumanMessageLocale(locale, code, string(verr.Body))
+	default:
+		msg = errC.HumanMessageLocale(locale, code, field)
+	}
+	envelope := verr.New()
+	envelope.Append(verr.ValidationError{Field: field, Message: msg, Loc: string(verr.Body), Code: code})
+	return envelope
+}
+
+// TypeErrorField returns the dotted path of the field that failed to decode
+// doc into t.
+//
+// Types with their own UnmarshalJSON (such as types.JSONB) report the field
+// relative to themselves, so the failing top-level key is located by decoding
+// the document one key at a time.
+func TypeErrorField(doc map[string]any, t reflect.Type, typeErr *json.UnmarshalTypeError) string {
+	if typeErr.Struct != "" || typeErr.Field == "" {
+		return typeErr.Field
+	}
+	keys := make([]string, 0, len(doc))
+	for k := range doc {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b, err := json.Marshal(map[string]any{k: doc[k]})
+		if err != nil {
+			continue
+		}
+		if json.Unmarshal(b, reflect.New(t).Interface()) != nil {
+			return k + "." + typeErr.Field
+		}
+	}
+	return typeErr.Field
+}
+
+// unknownFieldPath walks v alongside t and returns the path of the first
+// key named name that t does not declare.
+func unknownFieldPath(v any, t reflect.Type, name string) (string, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		return "", false
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, _ := v.(map[string]any)
+		fields := jsonFields(t)
+		for _, k := range sortedKeys(obj) {
+			ft, known := fields[strings.ToLower(k)]
+			if !known {
+				if k == name {
+					return k, true
+				}
+				continue
+			}
+			if p, ok := unknownFieldPath(obj[k], ft, name); ok {
+				return join(k, p), true
+			}
+		}
+	case reflect.Map:
+		obj, _ := v.(map[string]any)
+		for _, k := range sortedKeys(obj) {
+			if p, ok := unknownFieldPath(obj[k], t.Elem(), name); ok {
+				return join(k, p), true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		arr, _ := v.([]any)
+		for i, e := range arr {
+			if p, ok := unknownFieldPath(e, t.Elem(), name); ok {
+				return join(fmt.Sprintf("[%d]", i), p), true
+			}
+		}
+	}
+	return "", false
+}
+
+// jsonFields returns the JSON names (lowercased, as encoding/json matches
+// keys case-insensitively) of the fields of t, including promoted ones.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" && tag == "-" {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && ft.Kind() == reflect.Struct && (!tagged || name == "") {
+			for k, v := range jsonFields(ft) {
+				if _, ok := fields[k]; !ok {
+					fields[k] = v
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	return fields
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func join(parent, child string) string {
+	if strings.HasPrefix(child, "[") {
+		return parent + child
+	}
+	return parent + "." + child
+}
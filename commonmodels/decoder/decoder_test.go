@@ -0,0 +1,104 @@
+package decoder_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/decoder"
+	errC "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	verr "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
+)
+
+type owner struct {
+	ID string `json:"id"`
+}
+
+type dataset struct {
+	core.CoreModel
+	Owner  owner   `json:"owner"`
+	Items  []owner `json:"items"`
+	Public bool    `json:"public"`
+}
+
+func detail(t *testing.T, err error) verr.ValidationError {
+	t.Helper()
+	envelope, ok := verr.Extract(err)
+	require.True(t, ok, "expected an ErrorEnvelope, got %v", err)
+	require.Len(t, envelope.Details, 1)
+	assert.Equal(t, string(verr.Body), envelope.Details[0].Loc)
+	return envelope.Details[0]
+}
+
+func TestDecode_OK(t *testing.T) {
+	ds, err := decoder.Decode[dataset](strings.NewReader(`{"name":"orders","tags":{"team":"a"},"owner":{"id":"x"},"public":true}`), "en")
+	require.NoError(t, err)
+	assert.Equal(t, "orders", ds.Name)
+	assert.Equal(t, "a", ds.Tags.Data["team"])
+	assert.Equal(t, "x", ds.Owner.ID)
+	assert.True(t, ds.Public)
+}
+
+func TestDecode_errors(t *testing.T) {
+	cases := []struct {
+		name, body, field, code string
+	}{
+		{"empty", "  ", "", errC.Required},
+		{"syntax", `{"name":`, "", errC.InvalidJSONFormat},
+		{"malformed", `{"name" "x"}`, "", errC.InvalidJSONFormat},
+		{"trailing data", `{"name":"a"} {}`, "", errC.InvalidJSONFormat},
+		{"type", `{"public":"yes"}`, "public", errC.InvalidDataType},
+		{"nested type", `{"owner":{"id":1}}`, "owner.id", errC.InvalidDataType},
+		{"jsonb type", `{"tags":{"team":1}}`, "tags.team", errC.InvalidDataType},
+		{"unknown", `{"name":"a","nme":"b"}`, "nme", errC.UnknownField},
+		{"nested unknown", `{"owner":{"id":"x","email":"y"}}`, "owner.email", errC.UnknownField},
+		{"unknown in array", `{"items":[{"id":"x"},{"email":"y"}]}`, "items[1].email", errC.UnknownField},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := decoder.Decode[dataset](strings.NewReader(tc.body), "en")
+			d := detail(t, err)
+			assert.Equal(t, tc.field, d.Field)
+			assert.Equal(t, tc.code, d.Code)
+			assert.NotEmpty(t, d.Message)
+			assert.NotContains(t, d.Message, "%!")
+		})
+	}
+}
+
+func TestDecoder_options(t *testing.T) {
+	var ds dataset
+	err := decoder.Decoder{AllowUnknownFields: true}.Decode(strings.NewReader(`{"name":"a","nme":"b"}`), &ds)
+	require.NoError(t, err)
+	assert.Equal(t, "a", ds.Name)
+
+	err = decoder.Decoder{MaxBytes: 8}.Decode(strings.NewReader(`{"name":"orders"}`), &ds)
+	assert.Equal(t, errC.ContentTooLarge, detail(t, err).Code)
+
+	err = decoder.Decoder{Locale: "nb"}.Decode(strings.NewReader(`{"nme":"b"}`), &ds)
+	assert.Equal(t, "nme er ikke et kjent felt.", detail(t, err).Message)
+}
+
+func TestDecode_MaxBytesReader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"orders"}`))
+	body := http.MaxBytesReader(httptest.NewRecorder(), req.Body, 4)
+	_, err := decoder.Decode[dataset](body, "en")
+	assert.Equal(t, errC.ContentTooLarge, detail(t, err).Code)
+}
+
+func TestDecode_read_error(t *testing.T) {
+	boom := errors.New("boom")
+	_, err := decoder.Decode[dataset](errReader{boom}, "en")
+	assert.ErrorIs(t, err, boom)
+	assert.NotErrorIs(t, err, verr.ErrValidation)
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
@@ -1,16 +1,25 @@
 package entitlement
 
 import (
-	err "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/enum/errors"
-	val_err "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validation_error"
+	err "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	val_err "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
 )
 
 type Entitlement struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// TenantId is kept as a string for wire compatibility; use TenantID for
+	// the typed value.
 	TenantId string `json:"tenant_id"`
 }
 
+// TenantID parses TenantId. Validate does not require TenantId to be a
+// UUID, so callers needing the typed value must handle the error.
+func (e *Entitlement) TenantID() (ids.TenantID, error) {
+	return ids.Parse[ids.Tenant](e.TenantId)
+}
+
 func (e *Entitlement) Validate(locale string) []val_err.ValidationError {
 	var errors []val_err.ValidationError
 	if e.ID == "" {
@@ -7,7 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
-	models "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/entitlement"
+	models "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/entitlement"
 )
 
 func TestEntitlement_JSON_MarshalBasic(t *testing.T) {
@@ -80,3 +80,19 @@ func TestValidateLocaleNo(t *testing.T) {
 		}
 	}
 }
+
+func TestEntitlement_TenantID(t *testing.T) {
+	tenantID := uuid.New()
+	entitlement := models.Entitlement{ID: "x", Name: "Premium Access", TenantId: tenantID.String()}
+
+	typed, err := entitlement.TenantID()
+	assert.NoError(t, err)
+	assert.Equal(t, tenantID, typed.UUID())
+	assert.Empty(t, entitlement.Validate("en"))
+
+	// Non-UUID tenant IDs stay valid for existing callers.
+	entitlement.TenantId = "not-a-uuid"
+	_, err = entitlement.TenantID()
+	assert.Error(t, err)
+	assert.Empty(t, entitlement.Validate("en"))
+}
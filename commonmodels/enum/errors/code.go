@@ -90,6 +90,16 @@ const (
 	NotExtended                   = "not_extended"
 	NetworkAuthenticationRequired = "network_auth_required"
 	RequirePositiveInt            = "require_positive_int"
+	ImmutableField                = "immutable_field"
+	InvalidPatch                  = "invalid_patch"
+	InvalidKey                    = "invalid_key"
+	KeyTooLong                    = "key_too_long"
+	ValueTooLong                  = "value_too_long"
+	TooManyEntries                = "too_many_entries"
+	ReservedKey                   = "reserved_key"
+	TenantMismatch                = "tenant_mismatch"
+	InvalidRange                  = "invalid_range"
+	UnknownField                  = "unknown_field"
 )
 
 // -----------------------------------------------------------------------------
@@ -164,6 +174,16 @@ var messagesEN = map[string]string{
 	NotExtended:                   "Not extended.",
 	NetworkAuthenticationRequired: "Network authentication required.",
 	RequirePositiveInt:            "Integer must be positive.",
+	ImmutableField:                "%s cannot be changed.",
+	InvalidPatch:                  "Patch operation on %s could not be applied.",
+	InvalidKey:                    "%s is not a valid key.",
+	KeyTooLong:                    "The key of %s must be at most %s characters.",
+	ValueTooLong:                  "%s must be at most %s characters.",
+	TooManyEntries:                "%s must have at most %s entries.",
+	ReservedKey:                   "%s uses a reserved key.",
+	TenantMismatch:                "%s does not match tenant_id.",
+	InvalidRange:                  "%s must be after %s.",
+	UnknownField:                  "%s is not a known field.",
 }
 
 // -----------------------------------------------------------------------------
@@ -238,6 +258,16 @@ var messagesNB = map[string]string{
 	NotExtended:                   "Ikke utvidet.",
 	NetworkAuthenticationRequired: "Nettverksautentisering kreves.",
 	RequirePositiveInt:            "Heltallet må være positivt.",
+	ImmutableField:                "%s kan ikke endres.",
+	InvalidPatch:                  "Patch-operasjonen på %s kunne ikke utføres.",
+	InvalidKey:                    "%s er ikke en gyldig nøkkel.",
+	KeyTooLong:                    "Nøkkelen i %s kan ha maks %s tegn.",
+	ValueTooLong:                  "%s kan ha maks %s tegn.",
+	TooManyEntries:                "%s kan ha maks %s oppføringer.",
+	ReservedKey:                   "%s bruker en reservert nøkkel.",
+	TenantMismatch:                "%s samsvarer ikke med tenant_id.",
+	InvalidRange:                  "%s må være etter %s.",
+	UnknownField:                  "%s er ikke et kjent felt.",
 }
 
 // -----------------------------------------------------------------------------
@@ -374,6 +404,16 @@ var statusByCode = map[string]int{
 	NotExtended:                   http.StatusNotExtended,
 	NetworkAuthenticationRequired: http.StatusNetworkAuthenticationRequired,
 	RequirePositiveInt:            http.StatusBadRequest,
+	ImmutableField:                http.StatusUnprocessableEntity,
+	InvalidPatch:                  http.StatusBadRequest,
+	InvalidKey:                    http.StatusBadRequest,
+	KeyTooLong:                    http.StatusBadRequest,
+	ValueTooLong:                  http.StatusBadRequest,
+	TooManyEntries:                http.StatusBadRequest,
+	ReservedKey:                   http.StatusBadRequest,
+	TenantMismatch:                http.StatusBadRequest,
+	InvalidRange:                  http.StatusBadRequest,
+	UnknownField:                  http.StatusBadRequest,
 }
 
 func StatusFor(code string) int {
@@ -3,7 +3,7 @@ package errors_test
 import (
 	"testing"
 
-	e "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/enum/errors"
+	e "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
 )
 
 func TestCustomMessageLocale(t *testing.T) {
@@ -0,0 +1,96 @@
+package ids_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+const raw = "25948ccc-cf16-491e-9cd4-44d5ebb7bc54"
+
+func TestParse(t *testing.T) {
+	id, err := ids.Parse[ids.Tenant](raw)
+	require.NoError(t, err)
+	assert.Equal(t, raw, id.String())
+	assert.Equal(t, uuid.MustParse(raw), id.UUID())
+	assert.NoError(t, id.Validate())
+
+	_, err = ids.Parse[ids.Product]("nope")
+	assert.ErrorIs(t, err, ids.ErrInvalidID)
+	assert.Contains(t, err.Error(), "product id")
+
+	assert.ErrorIs(t, ids.TenantID{}.Validate(), ids.ErrInvalidID)
+	assert.True(t, ids.TenantID{}.IsZero())
+	assert.False(t, ids.New[ids.Entity]().IsZero())
+	assert.Panics(t, func() { ids.MustParse[ids.Tenant]("nope") })
+}
+
+func TestID_same_representation_as_uuid(t *testing.T) {
+	type typed struct {
+		TenantID  ids.TenantID   `json:"tenant_id"`
+		ProductID *ids.ProductID `json:"product_id,omitempty"`
+	}
+	type untyped struct {
+		TenantID  uuid.UUID  `json:"tenant_id"`
+		ProductID *uuid.UUID `json:"product_id,omitempty"`
+	}
+	u := uuid.MustParse(raw)
+	p := ids.ProductID(u)
+
+	a, err := json.Marshal(typed{TenantID: ids.TenantID(u), ProductID: &p})
+	require.NoError(t, err)
+	b, err := json.Marshal(untyped{TenantID: u, ProductID: &u})
+	require.NoError(t, err)
+	assert.JSONEq(t, string(b), string(a))
+
+	var back typed
+	require.NoError(t, json.Unmarshal(b, &back))
+	assert.Equal(t, raw, back.TenantID.String())
+	assert.Equal(t, u, back.ProductID.UUID())
+
+	m, err := json.Marshal(map[ids.TenantID]int{ids.TenantID(u): 1})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"`+raw+`":1}`, string(m))
+}
+
+func TestID_sql(t *testing.T) {
+	id := ids.MustParse[ids.Tenant](raw)
+	v, err := id.Value()
+	require.NoError(t, err)
+	assert.Equal(t, raw, v)
+
+	var scanned ids.TenantID
+	require.NoError(t, scanned.Scan(raw))
+	assert.Equal(t, id, scanned)
+	assert.Error(t, scanned.Scan(42))
+
+	assert.Equal(t, "uuid", id.GormDataType())
+}
+
+func TestNew_uses_UUIDv7(t *testing.T) {
+	a, b := ids.New[ids.Entity](), ids.New[ids.Entity]()
+	assert.Equal(t, uuid.Version(7), a.UUID().Version())
+	assert.LessOrEqual(t, a.String()[:13], b.String()[:13], "v7 IDs are time-ordered")
+
+	fixed := uuid.MustParse(raw)
+	old := ids.NewUUID
+	ids.NewUUID = func() uuid.UUID { return fixed }
+	defer func() { ids.NewUUID = old }()
+	assert.Equal(t, fixed, ids.New[ids.Tenant]().UUID())
+}
+
+func TestDerive(t *testing.T) {
+	ns := uuid.MustParse(raw)
+	a := ids.Derive[ids.Entity](ns, "tenant", "orders")
+	assert.Equal(t, uuid.Version(5), a.UUID().Version())
+	assert.Equal(t, a, ids.Derive[ids.Entity](ns, "tenant", "orders"))
+	assert.Equal(t, a.UUID(), ids.DeriveUUID(ns, "tenant", "orders"))
+
+	assert.NotEqual(t, a, ids.Derive[ids.Entity](ns, "tenan", "torders"))
+	assert.NotEqual(t, a, ids.Derive[ids.Entity](uuid.NameSpaceURL, "tenant", "orders"))
+}
@@ -0,0 +1,232 @@
+// Package jcs serializes JSON in the canonical form of RFC 8785 (JSON
+// Canonicalization Scheme), so that producers in any language emit the same
+// bytes, and therefore the same hash, for the same value:
+//
+//	b, _ := jcs.Marshal(map[string]any{"b": 1.0, "a": "<x>"})
+//	// {"a":"<x>","b":1}
+//
+// Object members are sorted by the UTF-16 code units of their names,
+// numbers use the ECMAScript number format, strings escape only what JSON
+// requires, and there is no whitespace.
+package jcs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalidJSON is returned (wrapped) for input that is not a single
+	// valid JSON value.
+	ErrInvalidJSON = errors.New("invalid JSON")
+	// ErrDuplicateKey is returned (wrapped) for objects with a repeated
+	// member name, which RFC 8785 (via I-JSON) forbids.
+	ErrDuplicateKey = errors.New("duplicate object key")
+	// ErrInvalidNumber is returned (wrapped) for numbers outside the range
+	// of an IEEE 754 double.
+	ErrInvalidNumber = errors.New("number not representable as a double")
+)
+
+// Marshal encodes v with encoding/json and returns its canonical form.
+func Marshal(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return Transform(b)
+}
+
+// Transform returns the canonical form of the JSON text data.
+func Transform(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var buf bytes.Buffer
+	if err := writeValue(&buf, dec); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("%w: trailing data", ErrInvalidJSON)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeValue(buf *bytes.Buffer, dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			return writeObject(buf, dec)
+		}
+		return writeArray(buf, dec)
+	case string:
+		writeString(buf, t)
+	case json.Number:
+		s, err := formatNumber(t)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(s)
+	case bool:
+		buf.WriteString(strconv.FormatBool(t))
+	case nil:
+		buf.WriteString("null")
+	}
+	return nil
+}
+
+func writeObject(buf *bytes.Buffer, dec *json.Decoder) error {
+	members := map[string][]byte{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+		}
+		key := tok.(string)
+		if _, dup := members[key]; dup {
+			return fmt.Errorf("%w: %q", ErrDuplicateKey, key)
+		}
+		var member bytes.Buffer
+		if err := writeValue(&member, dec); err != nil {
+			return err
+		}
+		members[key] = member.Bytes()
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+
+	keys := make([]string, 0, len(members))
+	for k := range members {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return lessUTF16(keys[i], keys[j]) })
+	buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeString(buf, k)
+		buf.WriteByte(':')
+		buf.Write(members[k])
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func writeArray(buf *bytes.Buffer, dec *json.Decoder) error {
+	buf.WriteByte('[')
+	for i := 0; dec.More(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeValue(buf, dec); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+// lessUTF16 orders strings by their UTF-16 code units, which differs from
+// Go's byte order for characters above U+FFFF.
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+// writeString escapes only quotes, backslashes and control characters,
+// using the short forms where JSON has them.
+func writeString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[r>>4])
+				buf.WriteByte(hex[r&0xf])
+			} else {
+				var tmp [utf8.UTFMax]byte
+				buf.Write(tmp[:utf8.EncodeRune(tmp[:], r)])
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// formatNumber formats n as ECMAScript's Number.prototype.toString does.
+func formatNumber(n json.Number) (string, error) {
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil || math.IsInf(f, 0) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidNumber, n)
+	}
+	if f == 0 {
+		return "0", nil
+	}
+
+	var sign string
+	if f < 0 {
+		sign, f = "-", -f
+	}
+	// Shortest round-tripping digits and exponent: d.ddde±x.
+	mantissa, exp, _ := strings.Cut(strconv.FormatFloat(f, 'e', -1, 64), "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	e, _ := strconv.Atoi(exp)
+	k, p := len(digits), e+1 // p is the position of the decimal point
+
+	var s string
+	switch {
+	case k <= p && p <= 21:
+		s = digits + strings.Repeat("0", p-k)
+	case 0 < p && p <= 21:
+		s = digits[:p] + "." + digits[p:]
+	case -6 < p && p <= 0:
+		s = "0." + strings.Repeat("0", -p) + digits
+	default:
+		s = digits[:1]
+		if k > 1 {
+			s += "." + digits[1:]
+		}
+		s += "e"
+		if p-1 >= 0 {
+			s += "+"
+		}
+		s += strconv.Itoa(p - 1)
+	}
+	return sign + s, nil
+}
@@ -0,0 +1,54 @@
+package jcs_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/jcs"
+)
+
+func TestTransform(t *testing.T) {
+	cases := map[string]struct{ in, want string }{
+		"whitespace and order": {`{ "b": [1, 2], "a": {"y": null, "x": true} }`, `{"a":{"x":true,"y":null},"b":[1,2]}`},
+		// RFC 8785 section 3.2.3: sorted by UTF-16 code units.
+		"utf16 order": {
+			`{"\u20ac":"Euro Sign","\r":"Carriage Return","\ufb33":"Hebrew Letter Dalet With Dagesh","1":"One","\ud83d\ude00":"Emoji: Grinning Face","\u0080":"Control","\u00f6":"Latin Small Letter O With Diaeresis"}`,
+			"{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\",\"\U0001F600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		"string escapes": {`"€\/<>&\u001f \t"`, `"€/<>&\u001f` + " " + `\t"`},
+		// RFC 8785 section 3.2.2.3 / appendix B.
+		"numbers": {
+			`[333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001, -0, 1e21, 1e-7, 100, 9007199254740993]`,
+			`[333333333.3333333,1e+30,4.5,0.002,1e-27,0,1e+21,1e-7,100,9007199254740992]`,
+		},
+		"small and large": {`[0.000001, 123456789012345680000, 5e-324, -1.7976931348623157e308]`, `[0.000001,123456789012345680000,5e-324,-1.7976931348623157e+308]`},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := jcs.Transform([]byte(tc.in))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestTransform_invalid(t *testing.T) {
+	_, err := jcs.Transform([]byte(`{"a":1,"a":2}`))
+	assert.ErrorIs(t, err, jcs.ErrDuplicateKey)
+
+	_, err = jcs.Transform([]byte(`[1e400]`))
+	assert.ErrorIs(t, err, jcs.ErrInvalidNumber)
+
+	for _, in := range []string{``, `{`, `{"a":}`, `[1] [2]`} {
+		_, err = jcs.Transform([]byte(in))
+		assert.ErrorIs(t, err, jcs.ErrInvalidJSON, in)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	got, err := jcs.Marshal(map[string]any{"b": 1.0, "a": "<x>", "c": []int{3, 1}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":"<x>","b":1,"c":[3,1]}`, string(got))
+}
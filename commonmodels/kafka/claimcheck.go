@@ -0,0 +1,272 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/jcs"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+)
+
+// DefaultClaimCheckThreshold is the size in bytes above which a ClaimCheck
+// without a Threshold offloads Payload or Context.
+const DefaultClaimCheckThreshold = 256 << 10
+
+var (
+	// ErrBlobNotFound is returned (wrapped) by a BlobStore for URIs without
+	// stored data.
+	ErrBlobNotFound = errors.New("blob not found")
+	// ErrUnsupportedURI is returned (wrapped) by a BlobStore for URIs it
+	// does not serve.
+	ErrUnsupportedURI = errors.New("unsupported blob URI")
+	// ErrInvalidBlob is returned (wrapped) when stored data is not a JSON
+	// object or a key is not a relative path.
+	ErrInvalidBlob = errors.New("invalid blob")
+	// ErrMissingHash is returned (wrapped) by Rehydrate for offloaded data
+	// without a hash to verify it against.
+	ErrMissingHash = errors.New("missing hash")
+)
+
+// BlobStore keeps offloaded event data.
+type BlobStore interface {
+	// Put stores data under the slash-separated key and returns a URI
+	// that Get accepts.
+	Put(ctx context.Context, key string, data []byte) (string, error)
+	// Get returns the data stored at uri.
+	Get(ctx context.Context, uri string) ([]byte, error)
+}
+
+// ClaimCheck moves large Payload and Context values of events to a Store so
+// that records stay below the broker's message limit:
+//
+//	cc := event.ClaimCheck{Store: &event.FileStore{Dir: "/var/lib/events"}}
+//	_ = cc.Offload(ctx, ev) // producer, before ev.Record()
+//	_ = cc.Rehydrate(ctx, ev) // consumer, after event.EventFromRecord
+//
+// The hash of offloaded data stays on the event, so it keeps its derived ID
+// and Rehydrate can verify what it reads back.
+type ClaimCheck struct {
+	Store BlobStore
+	// Threshold is the size of the canonical JSON in bytes above which
+	// a value is offloaded; zero means DefaultClaimCheckThreshold.
+	Threshold int
+}
+
+// Offload stores Payload and Context when their canonical JSON is larger
+// than the threshold, replacing them with PayloadURI and ContextURI. An
+// offloaded payload keeps its hash, computed with HashPayload when missing;
+// an offloaded context gets ContextHash. Both use the event's
+// HashAlgorithm, DefaultHashAlgorithm for events without one.
+func (c ClaimCheck) Offload(ctx context.Context, e *Event) error {
+	if e.Payload != nil {
+		b, err := jcs.Marshal(e.Payload)
+		if err != nil {
+			return fmt.Errorf("payload: %w", err)
+		}
+		if len(b) > c.threshold() {
+			if err := e.ensureHash(); err != nil {
+				return err
+			}
+			if e.PayloadHash == "" {
+				if err := e.HashPayload(e.HashAlgorithm); err != nil {
+					return err
+				}
+			}
+			if err := e.VerifyPayloadHash(); err != nil {
+				return err
+			}
+			uri, err := c.Store.Put(ctx, blobKey(e, "payload"), b)
+			if err != nil {
+				return err
+			}
+			e.Payload, e.PayloadURI = nil, &uri
+		}
+	}
+
+	if e.Context != nil {
+		b, err := jcs.Marshal(e.Context)
+		if err != nil {
+			return fmt.Errorf("context: %w", err)
+		}
+		if len(b) > c.threshold() {
+			if err := e.ensureHash(); err != nil {
+				return err
+			}
+			sum, err := e.HashAlgorithm.Sum(b)
+			if err != nil {
+				return err
+			}
+			uri, err := c.Store.Put(ctx, blobKey(e, "context"), b)
+			if err != nil {
+				return err
+			}
+			e.Context, e.ContextURI, e.ContextHash = nil, &uri, sum
+		}
+	}
+	return nil
+}
+
+// Rehydrate loads a missing Payload from PayloadURI and a missing Context
+// from ContextURI, and verifies them against PayloadHash (or MD5Hash) and
+// ContextHash. On error the field is left nil. Data without a hash is not
+// loaded and fails with ErrMissingHash; URIs the store does not serve fail
+// with ErrUnsupportedURI.
+func (c ClaimCheck) Rehydrate(ctx context.Context, e *Event) error {
+	if e.Payload == nil && e.PayloadURI != nil && *e.PayloadURI != "" {
+		if e.PayloadHash == "" && e.MD5Hash == "" {
+			return fmt.Errorf("%w: payload_hash", ErrMissingHash)
+		}
+		payload, err := c.load(ctx, *e.PayloadURI)
+		if err != nil {
+			return fmt.Errorf("payload: %w", err)
+		}
+		e.Payload = payload
+		if err := e.VerifyPayloadHash(); err != nil {
+			e.Payload = nil
+			return err
+		}
+	}
+
+	if e.Context == nil && e.ContextURI != nil && *e.ContextURI != "" {
+		if e.ContextHash == "" {
+			return fmt.Errorf("%w: context_hash", ErrMissingHash)
+		}
+		loaded, err := c.load(ctx, *e.ContextURI)
+		if err != nil {
+			return fmt.Errorf("context: %w", err)
+		}
+		sum, err := hashJSON(e.HashAlgorithm, loaded, false)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(sum, e.ContextHash) {
+			return fmt.Errorf("%w: context_hash", ErrHashMismatch)
+		}
+		e.Context = loaded
+	}
+	return nil
+}
+
+func (c ClaimCheck) threshold() int {
+	if c.Threshold > 0 {
+		return c.Threshold
+	}
+	return DefaultClaimCheckThreshold
+}
+
+func (c ClaimCheck) load(ctx context.Context, uri string) (*types.JSONB[map[string]any], error) {
+	b, err := c.Store.Get(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	var v types.JSONB[map[string]any]
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBlob, err)
+	}
+	return &v, nil
+}
+
+// ensureHash sets HashAlgorithm on events without one. A payload hashed
+// before HashAlgorithm existed is verified and hashed again.
+func (e *Event) ensureHash() error {
+	if e.HashAlgorithm != "" {
+		return nil
+	}
+	if e.Payload != nil {
+		if err := e.VerifyPayloadHash(); err != nil {
+			return err
+		}
+		return e.HashPayload(DefaultHashAlgorithm)
+	}
+	if e.MD5Hash != "" {
+		return fmt.Errorf("%w: md5_hash of an offloaded payload without hash_algorithm", ErrUnsupportedHash)
+	}
+	e.HashAlgorithm = DefaultHashAlgorithm
+	return nil
+}
+
+// blobKey is <tenant>/<event id>/<name>.json.
+func blobKey(e *Event, name string) string {
+	return path.Join(e.TenantID.String(), e.ID.String(), name+".json")
+}
+
+// FileStore is a BlobStore on the local filesystem. Keys are paths below
+// Dir and URIs are file:// URLs.
+type FileStore struct {
+	Dir string
+}
+
+// Put writes data to Dir/key, creating directories as needed. The file is
+// written to a temporary name first so readers never see partial data.
+func (s *FileStore) Put(ctx context.Context, key string, data []byte) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	p, err := s.path(filepath.FromSlash(key))
+	if err != nil {
+		return "", fmt.Errorf("%w: key %q", ErrInvalidBlob, key)
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(filepath.Dir(p), ".blob-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(f.Name(), p); err != nil {
+		return "", err
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(p)}).String(), nil
+}
+
+// Get reads the file at a file:// uri below Dir.
+func (s *FileStore) Get(ctx context.Context, uri string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" || (u.Host != "" && u.Host != "localhost") {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedURI, uri)
+	}
+	root, err := filepath.Abs(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(root, filepath.FromSlash(u.Path))
+	if err != nil || !filepath.IsLocal(rel) {
+		return nil, fmt.Errorf("%w: %s is outside %s", ErrUnsupportedURI, uri, root)
+	}
+	b, err := os.ReadFile(filepath.Join(root, rel))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, uri)
+	}
+	return b, err
+}
+
+// path returns the absolute path of the relative path rel below Dir.
+func (s *FileStore) path(rel string) (string, error) {
+	if !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("%q is not a local path", rel)
+	}
+	root, err := filepath.Abs(s.Dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, rel), nil
+}
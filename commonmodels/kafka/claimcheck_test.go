@@ -0,0 +1,179 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	events "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/kafka"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+)
+
+func largeEvent(t *testing.T) *events.Event {
+	t.Helper()
+	ev := newValidEvent()
+	ev.MD5Hash = ""
+	ev.Payload = &types.JSONB[map[string]any]{Data: map[string]any{"rows": strings.Repeat("x", 2048)}}
+	ev.Context = &types.JSONB[map[string]any]{Data: map[string]any{"trace": strings.Repeat("y", 2048)}}
+	ev.ContextURI = nil
+	if err := ev.HashPayload(events.HashSHA256); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ev.DeriveID()
+	return &ev
+}
+
+func TestClaimCheck_roundtrip(t *testing.T) {
+	ctx := context.Background()
+	cc := events.ClaimCheck{Store: &events.FileStore{Dir: t.TempDir()}, Threshold: 1024}
+	ev := largeEvent(t)
+	orig := *ev
+
+	if err := cc.Offload(ctx, ev); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ev.Payload != nil || ev.Context != nil {
+		t.Fatalf("expected payload and context to be offloaded")
+	}
+	if ev.PayloadURI == nil || !strings.HasPrefix(*ev.PayloadURI, "file:///") || ev.ContextURI == nil {
+		t.Fatalf("expected file URIs, got %v %v", ev.PayloadURI, ev.ContextURI)
+	}
+	if ev.PayloadHash != orig.PayloadHash || ev.ID != orig.ID || ev.ContextHash == "" {
+		t.Fatalf("expected the payload hash and ID to be kept and a context hash, got %+v", ev)
+	}
+	if errs := ev.Validate(); len(errs) != 0 {
+		t.Fatalf("expected an offloaded event to be valid, got: %+v", errs)
+	}
+
+	r, err := ev.Record()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	back, err := events.EventFromRecord(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cc.Rehydrate(ctx, back); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(back.Payload, orig.Payload) || !reflect.DeepEqual(back.Context, orig.Context) {
+		t.Fatalf("expected the original payload and context back")
+	}
+	if errs := back.Validate(); len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %+v", errs)
+	}
+}
+
+func TestClaimCheck_below_threshold(t *testing.T) {
+	cc := events.ClaimCheck{Store: &events.FileStore{Dir: t.TempDir()}}
+	ev := newValidEvent()
+	if err := cc.Offload(context.Background(), &ev); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ev.Payload == nil || ev.PayloadURI != nil || ev.Context == nil {
+		t.Fatalf("expected small values to stay inline")
+	}
+	// Nothing to load.
+	if err := cc.Rehydrate(context.Background(), &ev); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClaimCheck_tampered(t *testing.T) {
+	ctx := context.Background()
+	cc := events.ClaimCheck{Store: &events.FileStore{Dir: t.TempDir()}, Threshold: 1024}
+	ev := largeEvent(t)
+	if err := cc.Offload(ctx, ev); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, uri := range []string{*ev.PayloadURI, *ev.ContextURI} {
+		p := strings.TrimPrefix(uri, "file://")
+		if err := os.WriteFile(p, []byte(`{"rows":"changed","trace":"changed"}`), 0o600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	payloadOnly := *ev
+	payloadOnly.ContextURI = nil
+	if err := cc.Rehydrate(ctx, &payloadOnly); !errors.Is(err, events.ErrHashMismatch) || payloadOnly.Payload != nil {
+		t.Fatalf("expected ErrHashMismatch and no payload, got %v", err)
+	}
+	contextOnly := *ev
+	contextOnly.PayloadURI = nil
+	if err := cc.Rehydrate(ctx, &contextOnly); !errors.Is(err, events.ErrHashMismatch) || contextOnly.Context != nil {
+		t.Fatalf("expected ErrHashMismatch and no context, got %v", err)
+	}
+}
+
+func TestClaimCheck_missing_hash(t *testing.T) {
+	ctx := context.Background()
+	cc := events.ClaimCheck{Store: &events.FileStore{Dir: t.TempDir()}, Threshold: 1024}
+	ev := largeEvent(t)
+	if err := cc.Offload(ctx, ev); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	contextOnly := *ev
+	contextOnly.PayloadURI, contextOnly.ContextHash = nil, ""
+	if err := cc.Rehydrate(ctx, &contextOnly); !errors.Is(err, events.ErrMissingHash) || contextOnly.Context != nil {
+		t.Fatalf("expected ErrMissingHash and no context, got %v", err)
+	}
+	payloadOnly := *ev
+	payloadOnly.ContextURI, payloadOnly.PayloadHash, payloadOnly.MD5Hash = nil, "", ""
+	if err := cc.Rehydrate(ctx, &payloadOnly); !errors.Is(err, events.ErrMissingHash) || payloadOnly.Payload != nil {
+		t.Fatalf("expected ErrMissingHash and no payload, got %v", err)
+	}
+}
+
+func TestClaimCheck_file_uri_only_for_blobs(t *testing.T) {
+	ctx := context.Background()
+	cc := events.ClaimCheck{Store: &events.FileStore{Dir: t.TempDir()}, Threshold: 1024}
+	ev := largeEvent(t)
+	if err := cc.Offload(ctx, ev); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	unhashed := *ev
+	unhashed.ContextHash = ""
+	if !hasErr(unhashed.Validate(), "context_uri", "invalid URI") {
+		t.Fatalf("expected a context_uri error for a file URI without context_hash")
+	}
+	source := *ev
+	source.EventSourceURI = ev.PayloadURI
+	if !hasErr(source.Validate(), "event_source_uri", "invalid URI") {
+		t.Fatalf("expected an event_source_uri error for a file URI")
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := &events.FileStore{Dir: dir}
+
+	uri, err := store.Put(ctx, "a/b.json", []byte(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "file://" + filepath.ToSlash(filepath.Join(dir, "a", "b.json")); uri != want {
+		t.Fatalf("expected %s, got %s", want, uri)
+	}
+	if b, err := store.Get(ctx, uri); err != nil || string(b) != `{}` {
+		t.Fatalf("expected the stored data, got %s (%v)", b, err)
+	}
+
+	if _, err := store.Put(ctx, "../escape.json", nil); !errors.Is(err, events.ErrInvalidBlob) {
+		t.Fatalf("expected ErrInvalidBlob for a key outside the store, got %v", err)
+	}
+	if _, err := store.Get(ctx, "file://"+dir+"/missing.json"); !errors.Is(err, events.ErrBlobNotFound) {
+		t.Fatalf("expected ErrBlobNotFound, got %v", err)
+	}
+	for _, u := range []string{"s3://bucket/a.json", "file:///etc/passwd", "file://" + dir + "/../x.json"} {
+		if _, err := store.Get(ctx, u); !errors.Is(err, events.ErrUnsupportedURI) {
+			t.Fatalf("expected ErrUnsupportedURI for %s, got %v", u, err)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package event
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+)
+
+const (
+	// CloudEventsSpecVersion is the CloudEvents version produced and accepted.
+	CloudEventsSpecVersion = "1.0"
+	// CloudEventsContentType is the content type of structured-mode events.
+	CloudEventsContentType = "application/cloudevents+json"
+
+	headerPrefix      = "ce_"
+	headerContentType = "content-type"
+	jsonContentType   = "application/json"
+)
+
+// ErrInvalidCloudEvent is returned (wrapped) for CloudEvents that are
+// malformed or cannot be mapped to an Event.
+var ErrInvalidCloudEvent = errors.New("invalid cloudevent")
+
+// Header is a Kafka record header.
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// CloudEvent is a CloudEvents 1.0 event: its context attributes, in their
+// canonical string form, and its JSON data.
+//
+// Event maps to a CloudEvent as follows. Fields without a CloudEvents
+// attribute become extension attributes; maps are JSON encoded.
+//
+//	ID                id
+//	EventSource       source
+//	EventType         type
+//	Timestamp         time
+//	AffectedEntityURI subject
+//	Payload           data (datacontenttype application/json)
+//	PayloadURI        dataref
+//	SessionID         sessionid
+//	RequestID         requestid
+//	TenantID          tenantid
+//	OwnerID           ownerid
+//	EventSourceURI    sourceuri
+//	Message           message
+//	CreatedBy         createdby
+//	HashAlgorithm     hashalgorithm
+//	PayloadHash       payloadhash
+//	MD5Hash           md5hash
+//	Context           context
+//	ContextURI        contexturi
+//	ContextHash       contexthash
+//	Metadata          metadata
+//	Tags              tags
+type CloudEvent struct {
+	Attributes map[string]string
+	Data       json.RawMessage
+}
+
+// CloudEvent converts e to a CloudEvent.
+func (e *Event) CloudEvent() (CloudEvent, error) {
+	attrs := map[string]string{
+		"specversion": CloudEventsSpecVersion,
+		"id":          e.ID.String(),
+		"source":      e.EventSource,
+		"type":        e.EventType,
+	}
+	set := func(name string, v *string) {
+		if v != nil {
+			attrs[name] = *v
+		}
+	}
+	setID := func(name string, id typedID) {
+		if v := idString(id); v != "" {
+			attrs[name] = v
+		}
+	}
+	setJSON := func(name string, v any, present bool) error {
+		if !present {
+			return nil
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		attrs[name] = string(b)
+		return nil
+	}
+
+	if !e.Timestamp.IsZero() {
+		attrs["time"] = e.Timestamp.Format(time.RFC3339Nano)
+	}
+	set("subject", e.AffectedEntityURI)
+	set("dataref", e.PayloadURI)
+	setID("sessionid", e.SessionID)
+	setID("requestid", e.RequestID)
+	setID("tenantid", e.TenantID)
+	set("ownerid", e.OwnerID)
+	set("sourceuri", e.EventSourceURI)
+	set("message", e.Message)
+	set("contexturi", e.ContextURI)
+	if e.ContextHash != "" {
+		attrs["contexthash"] = e.ContextHash
+	}
+	if e.CreatedBy != "" {
+		attrs["createdby"] = e.CreatedBy
+	}
+	if e.HashAlgorithm != "" {
+		attrs["hashalgorithm"] = string(e.HashAlgorithm)
+	}
+	if e.PayloadHash != "" {
+		attrs["payloadhash"] = e.PayloadHash
+	}
+	if e.MD5Hash != "" {
+		attrs["md5hash"] = e.MD5Hash
+	}
+	if err := setJSON("context", e.Context, e.Context != nil); err != nil {
+		return CloudEvent{}, err
+	}
+	if err := setJSON("metadata", e.Metadata.Data, e.Metadata.Data != nil); err != nil {
+		return CloudEvent{}, err
+	}
+	if err := setJSON("tags", e.Tags.Data, e.Tags.Data != nil); err != nil {
+		return CloudEvent{}, err
+	}
+
+	ce := CloudEvent{Attributes: attrs}
+	if e.Payload != nil {
+		b, err := json.Marshal(e.Payload)
+		if err != nil {
+			return CloudEvent{}, fmt.Errorf("data: %w", err)
+		}
+		attrs["datacontenttype"] = jsonContentType
+		ce.Data = b
+	}
+	return ce, nil
+}
+
+// EventFromCloudEvent converts a CloudEvent to an Event. It is the inverse
+// of Event.CloudEvent; unknown extension attributes are ignored.
+func EventFromCloudEvent(ce CloudEvent) (*Event, error) {
+	if err := ce.Validate(); err != nil {
+		return nil, err
+	}
+	a := ce.Attributes
+	e := &Event{EventSource: a["source"], EventType: a["type"], CreatedBy: a["createdby"], MD5Hash: a["md5hash"]}
+	e.HashAlgorithm, e.PayloadHash, e.ContextHash = HashAlgorithm(a["hashalgorithm"]), a["payloadhash"], a["contexthash"]
+	var err error
+	if e.ID, err = uuid.Parse(a["id"]); err != nil {
+		return nil, fmt.Errorf("%w: id: %v", ErrInvalidCloudEvent, err)
+	}
+	get := func(name string) *string {
+		if v, ok := a[name]; ok {
+			return &v
+		}
+		return nil
+	}
+	e.AffectedEntityURI = get("subject")
+	e.PayloadURI = get("dataref")
+	e.OwnerID = get("ownerid")
+	e.EventSourceURI = get("sourceuri")
+	e.Message = get("message")
+	e.ContextURI = get("contexturi")
+
+	if v, ok := a["time"]; ok {
+		if e.Timestamp, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return nil, fmt.Errorf("%w: time: %v", ErrInvalidCloudEvent, err)
+		}
+	}
+	if err := parseAttrID(a, "sessionid", &e.SessionID); err != nil {
+		return nil, err
+	}
+	if err := parseAttrID(a, "requestid", &e.RequestID); err != nil {
+		return nil, err
+	}
+	if err := parseAttrID(a, "tenantid", &e.TenantID); err != nil {
+		return nil, err
+	}
+	parseJSON := func(name string, dst any) error {
+		if v, ok := a[name]; ok {
+			if err := json.Unmarshal([]byte(v), dst); err != nil {
+				return fmt.Errorf("%w: %s: %v", ErrInvalidCloudEvent, name, err)
+			}
+		}
+		return nil
+	}
+	if _, ok := a["context"]; ok {
+		e.Context = &types.JSONB[map[string]any]{}
+		if err := parseJSON("context", e.Context); err != nil {
+			return nil, err
+		}
+	}
+	if err := parseJSON("metadata", &e.Metadata); err != nil {
+		return nil, err
+	}
+	if err := parseJSON("tags", &e.Tags); err != nil {
+		return nil, err
+	}
+
+	if ce.Data != nil {
+		if ct, ok := a["datacontenttype"]; ok && !isJSONContentType(ct) {
+			return nil, fmt.Errorf("%w: unsupported datacontenttype %q", ErrInvalidCloudEvent, ct)
+		}
+		e.Payload = &types.JSONB[map[string]any]{}
+		if err := json.Unmarshal(ce.Data, e.Payload); err != nil {
+			return nil, fmt.Errorf("%w: data: %v", ErrInvalidCloudEvent, err)
+		}
+	}
+	return e, nil
+}
+
+// typedID is satisfied by every ids.ID.
+type typedID interface {
+	IsZero() bool
+	String() string
+}
+
+// idString returns id as a string, or "" for the zero ID.
+func idString(id typedID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.String()
+}
+
+func parseAttrID[K ids.Kind](a map[string]string, name string, dst *ids.ID[K]) error {
+	v, ok := a[name]
+	if !ok {
+		return nil
+	}
+	id, err := ids.Parse[K](v)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidCloudEvent, name, err)
+	}
+	*dst = id
+	return nil
+}
+
+// Validate checks the required context attributes and attribute names.
+func (ce CloudEvent) Validate() error {
+	if v := ce.Attributes["specversion"]; v != CloudEventsSpecVersion {
+		return fmt.Errorf("%w: unsupported specversion %q", ErrInvalidCloudEvent, v)
+	}
+	for _, name := range []string{"id", "source", "type"} {
+		if ce.Attributes[name] == "" {
+			return fmt.Errorf("%w: %s is required", ErrInvalidCloudEvent, name)
+		}
+	}
+	for name := range ce.Attributes {
+		if !validAttributeName(name) {
+			return fmt.Errorf("%w: invalid attribute name %q", ErrInvalidCloudEvent, name)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes ce in structured mode.
+func (ce CloudEvent) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(ce.Attributes)+1)
+	for k, v := range ce.Attributes {
+		m[k] = v
+	}
+	if ce.Data != nil {
+		m["data"] = ce.Data
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes a structured-mode event. Non-string extension
+// values (numbers, booleans) are kept in their JSON form; data_base64 must
+// hold JSON.
+func (ce *CloudEvent) UnmarshalJSON(b []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCloudEvent, err)
+	}
+	out := CloudEvent{Attributes: make(map[string]string, len(m))}
+	for k, raw := range m {
+		switch k {
+		case "data":
+			out.Data = raw
+		case "data_base64":
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return fmt.Errorf("%w: data_base64: %v", ErrInvalidCloudEvent, err)
+			}
+			data, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return fmt.Errorf("%w: data_base64: %v", ErrInvalidCloudEvent, err)
+			}
+			out.Data = data
+		default:
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				s = string(raw)
+			}
+			out.Attributes[k] = s
+		}
+	}
+	*ce = out
+	return nil
+}
+
+// Binary encodes ce in Kafka binary mode: one ce_<name> header per
+// attribute, datacontenttype as the content-type header, and the data as
+// the record value.
+func (ce CloudEvent) Binary() ([]Header, []byte) {
+	names := make([]string, 0, len(ce.Attributes))
+	for k := range ce.Attributes {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	headers := make([]Header, 0, len(names))
+	for _, k := range names {
+		key := headerPrefix + k
+		if k == "datacontenttype" {
+			key = headerContentType
+		}
+		headers = append(headers, Header{Key: key, Value: []byte(ce.Attributes[k])})
+	}
+	return headers, ce.Data
+}
+
+// Structured encodes ce in Kafka structured mode: a content-type header and
+// the JSON event as the record value.
+func (ce CloudEvent) Structured() ([]Header, []byte, error) {
+	b, err := json.Marshal(ce)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []Header{{Key: headerContentType, Value: []byte(CloudEventsContentType)}}, b, nil
+}
+
+// ParseCloudEvent decodes a Kafka record in either mode, telling them apart
+// by the content-type header. A binary mode value without a content-type
+// header is read as application/json.
+func ParseCloudEvent(headers []Header, value []byte) (CloudEvent, error) {
+	var ce CloudEvent
+	contentType, _ := headerValue(headers, headerContentType)
+	if strings.HasPrefix(contentType, "application/cloudevents") {
+		if err := json.Unmarshal(value, &ce); err != nil {
+			return CloudEvent{}, err
+		}
+		return ce, ce.Validate()
+	}
+
+	ce.Attributes = map[string]string{}
+	for _, h := range headers {
+		if name, ok := strings.CutPrefix(strings.ToLower(h.Key), headerPrefix); ok {
+			ce.Attributes[name] = string(h.Value)
+		}
+	}
+	if len(value) > 0 {
+		// Binary mode records without a content-type carry JSON data.
+		if contentType == "" {
+			contentType = jsonContentType
+		}
+		ce.Data = bytes.Clone(value)
+	}
+	if contentType != "" {
+		ce.Attributes["datacontenttype"] = contentType
+	}
+	return ce, ce.Validate()
+}
+
+func headerValue(headers []Header, key string) (string, bool) {
+	for _, h := range headers {
+		if strings.EqualFold(h.Key, key) {
+			return string(h.Value), true
+		}
+	}
+	return "", false
+}
+
+// validAttributeName reports whether name consists of lowercase letters
+// and digits, as CloudEvents requires.
+func validAttributeName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isJSONContentType(ct string) bool {
+	mediaType, _, _ := strings.Cut(ct, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+	return mediaType == jsonContentType || strings.HasSuffix(mediaType, "+json")
+}
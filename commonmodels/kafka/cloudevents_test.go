@@ -0,0 +1,239 @@
+package event_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	events "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/kafka"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+)
+
+// fullEvent sets every field of Event.
+func fullEvent() *events.Event {
+	return &events.Event{
+		ID:                uuid.New(),
+		SessionID:         ids.New[ids.Session](),
+		RequestID:         ids.New[ids.Request](),
+		TenantID:          ids.New[ids.Tenant](),
+		OwnerID:           strp("owner-1"),
+		EventType:         "datasets.created",
+		EventSource:       "dataset-api",
+		EventSourceURI:    strp("https://example.com/source"),
+		AffectedEntityURI: strp("urn:grasp:25948ccc-cf16-491e-9cd4-44d5ebb7bc54:datasets:1"),
+		Message:           strp("created"),
+		Payload:           &types.JSONB[map[string]any]{Data: map[string]any{"k": "v", "n": float64(1), "nested": map[string]any{"a": []any{true}}}},
+		PayloadURI:        strp("s3://bucket/payload.json"),
+		Metadata:          types.JSONB[map[string]string]{Data: map[string]string{"m": "1"}},
+		Tags:              types.JSONB[map[string]string]{Data: map[string]string{"env": "test"}},
+		Timestamp:         time.Date(2025, 8, 18, 12, 0, 0, 123456789, time.UTC),
+		CreatedBy:         "dev@example.com",
+		HashAlgorithm:     events.HashSHA256,
+		PayloadHash:       validSHA256(),
+		MD5Hash:           validMD5(),
+		Context:           &types.JSONB[map[string]any]{Data: map[string]any{"trace": "abc"}},
+		ContextURI:        strp("https://example.com/ctx"),
+		ContextHash:       validSHA256(),
+	}
+}
+
+func TestCloudEvent_roundtrip_structured(t *testing.T) {
+	for name, e := range map[string]*events.Event{"full": fullEvent(), "minimal": minimalEvent()} {
+		t.Run(name, func(t *testing.T) {
+			ce, err := e.CloudEvent()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			headers, value, err := ce.Structured()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			parsed, err := events.ParseCloudEvent(headers, value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			back, err := events.EventFromCloudEvent(parsed)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(e, back) {
+				t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", back, e)
+			}
+		})
+	}
+}
+
+func TestCloudEvent_roundtrip_binary(t *testing.T) {
+	for name, e := range map[string]*events.Event{"full": fullEvent(), "minimal": minimalEvent()} {
+		t.Run(name, func(t *testing.T) {
+			ce, err := e.CloudEvent()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			headers, value := ce.Binary()
+			parsed, err := events.ParseCloudEvent(headers, value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			back, err := events.EventFromCloudEvent(parsed)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(e, back) {
+				t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", back, e)
+			}
+		})
+	}
+}
+
+func TestCloudEvent_attributes(t *testing.T) {
+	e := fullEvent()
+	ce, err := e.CloudEvent()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"specversion":     "1.0",
+		"id":              e.ID.String(),
+		"source":          "dataset-api",
+		"type":            "datasets.created",
+		"time":            "2025-08-18T12:00:00.123456789Z",
+		"subject":         *e.AffectedEntityURI,
+		"datacontenttype": "application/json",
+		"dataref":         "s3://bucket/payload.json",
+		"tenantid":        e.TenantID.String(),
+		"md5hash":         validMD5(),
+		"tags":            `{"env":"test"}`,
+	}
+	for k, v := range want {
+		if ce.Attributes[k] != v {
+			t.Fatalf("attribute %s: expected %q, got %q", k, v, ce.Attributes[k])
+		}
+	}
+
+	headers, value := ce.Binary()
+	found := map[string]string{}
+	for _, h := range headers {
+		found[h.Key] = string(h.Value)
+	}
+	if found["ce_id"] != e.ID.String() || found["content-type"] != "application/json" || found["ce_tenantid"] != e.TenantID.String() {
+		t.Fatalf("unexpected headers: %v", found)
+	}
+	if _, ok := found["ce_datacontenttype"]; ok {
+		t.Fatalf("datacontenttype must be sent as content-type")
+	}
+	var data map[string]any
+	if err := json.Unmarshal(value, &data); err != nil || data["k"] != "v" {
+		t.Fatalf("expected the payload as value, got %s (%v)", value, err)
+	}
+}
+
+func TestCloudEvent_binary_without_content_type(t *testing.T) {
+	e := fullEvent()
+	ce, err := e.CloudEvent()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	headers, value := ce.Binary()
+	var stripped []events.Header
+	for _, h := range headers {
+		if h.Key != "content-type" {
+			stripped = append(stripped, h)
+		}
+	}
+	if len(stripped) == len(headers) {
+		t.Fatalf("expected a content-type header in %v", headers)
+	}
+
+	parsed, err := events.ParseCloudEvent(stripped, value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(parsed.Data) != string(value) || parsed.Attributes["datacontenttype"] != "application/json" {
+		t.Fatalf("record value dropped: %+v", parsed)
+	}
+	back, err := events.EventFromCloudEvent(parsed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(e.Payload, back.Payload) {
+		t.Fatalf("payload mismatch: got %+v want %+v", back.Payload, e.Payload)
+	}
+}
+
+func TestCloudEvent_from_partner(t *testing.T) {
+	raw := `{
+		"specversion": "1.0",
+		"id": "` + uuid.NewString() + `",
+		"source": "https://partner.example.com",
+		"type": "com.example.order.created",
+		"time": "2025-08-18T14:00:00+02:00",
+		"tenantid": "25948ccc-cf16-491e-9cd4-44d5ebb7bc54",
+		"priority": 5,
+		"datacontenttype": "application/json",
+		"data_base64": "eyJvcmRlciI6MX0="
+	}`
+	headers := []events.Header{{Key: "Content-Type", Value: []byte("application/cloudevents+json; charset=utf-8")}}
+	ce, err := events.ParseCloudEvent(headers, []byte(raw))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ce.Attributes["priority"] != "5" {
+		t.Fatalf("expected numeric extension as string, got %q", ce.Attributes["priority"])
+	}
+	e, err := events.EventFromCloudEvent(ce)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Payload.Data["order"] != float64(1) || e.TenantID.String() != "25948ccc-cf16-491e-9cd4-44d5ebb7bc54" {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+	if !e.Timestamp.Equal(time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected time: %s", e.Timestamp)
+	}
+}
+
+func TestCloudEvent_invalid(t *testing.T) {
+	valid := func() events.CloudEvent {
+		ce, _ := minimalEvent().CloudEvent()
+		return ce
+	}
+	cases := map[string]func(ce *events.CloudEvent){
+		"spec version":   func(ce *events.CloudEvent) { ce.Attributes["specversion"] = "0.3" },
+		"missing source": func(ce *events.CloudEvent) { delete(ce.Attributes, "source") },
+		"attribute name": func(ce *events.CloudEvent) { ce.Attributes["Tenant_ID"] = "x" },
+		"id":             func(ce *events.CloudEvent) { ce.Attributes["id"] = "not-a-uuid" },
+		"tenant":         func(ce *events.CloudEvent) { ce.Attributes["tenantid"] = "x" },
+		"time":           func(ce *events.CloudEvent) { ce.Attributes["time"] = "yesterday" },
+		"content type": func(ce *events.CloudEvent) {
+			ce.Attributes["datacontenttype"] = "text/plain"
+			ce.Data = []byte("hi")
+		},
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			ce := valid()
+			mutate(&ce)
+			if _, err := events.EventFromCloudEvent(ce); !errors.Is(err, events.ErrInvalidCloudEvent) {
+				t.Fatalf("expected ErrInvalidCloudEvent, got %v", err)
+			}
+		})
+	}
+	if _, err := events.ParseCloudEvent(nil, nil); !errors.Is(err, events.ErrInvalidCloudEvent) {
+		t.Fatalf("expected ErrInvalidCloudEvent for a record without attributes, got %v", err)
+	}
+}
+
+// minimalEvent leaves every optional field unset.
+func minimalEvent() *events.Event {
+	return &events.Event{
+		ID:          uuid.New(),
+		EventType:   "ping",
+		EventSource: "unit-test",
+	}
+}
@@ -0,0 +1,121 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/schema"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+)
+
+// ErrInvalidEvent is returned (wrapped) by FromEntity when the resulting
+// event does not pass Validate.
+var ErrInvalidEvent = errors.New("invalid event")
+
+// Action is the lifecycle change an entity event reports.
+type Action string
+
+const (
+	ActionCreated Action = "created"
+	ActionUpdated Action = "updated"
+	ActionDeleted Action = "deleted"
+)
+
+// RequestContext identifies the request causing an entity event.
+type RequestContext struct {
+	SessionID ids.SessionID
+	RequestID ids.RequestID
+	// Actor becomes CreatedBy; the subject in ctx (see core.WithSubject)
+	// when empty.
+	Actor string
+	// Source is the EventSource, typically the service name.
+	Source string
+}
+
+// FromEntity builds the Event announcing that entity was created, updated
+// or deleted:
+//
+//	ev, err := event.FromEntity(ctx, &ds, event.ActionCreated, event.RequestContext{
+//		SessionID: sessionID,
+//		RequestID: requestID,
+//		Source:    "dataset-api",
+//	})
+//
+// EventType is "<resource>.<action>" (e.g. "datasets.created"), where the
+// resource is the entity's GORM table name, which is also used in the
+// AffectedEntityURI URN. The entity's JSON is the Payload and its tags are
+// copied. Timestamp comes from the clock in ctx (see clock.WithClock) and
+// the ID is derived (see DeriveID), so retrying a request yields the same
+// event. The returned event passes Validate; otherwise the error wraps
+// ErrInvalidEvent.
+func FromEntity(ctx context.Context, entity core.Entity, action Action, rc RequestContext) (*Event, error) {
+	if action == "" {
+		return nil, fmt.Errorf("%w: action is required", ErrInvalidEvent)
+	}
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil, err
+	}
+
+	c := entity.Core()
+	actor := rc.Actor
+	if actor == "" {
+		actor, _ = core.SubjectFromContext(ctx)
+	}
+	resource := resourceName(entity)
+	e := &Event{
+		SessionID:   rc.SessionID,
+		RequestID:   rc.RequestID,
+		TenantID:    c.TenantID,
+		EventType:   resource + "." + string(action),
+		EventSource: rc.Source,
+		Payload:     &types.JSONB[map[string]any]{Data: payload},
+		Tags:        types.JSONB[map[string]string]{Data: maps.Clone(c.Tags.Data)},
+		CreatedBy:   actor,
+	}
+	if c.OwnerID != "" {
+		owner := c.OwnerID
+		e.OwnerID = &owner
+	}
+	if !c.TenantID.IsZero() {
+		e.AffectedEntityURI = c.URN(resource).Ptr()
+	}
+	e.Stamp(ctx)
+	if err := e.HashPayload(DefaultHashAlgorithm); err != nil {
+		return nil, err
+	}
+	e.DeriveID()
+
+	if errs := e.Validate(); len(errs) > 0 {
+		fields := make([]string, len(errs))
+		for i, ve := range errs {
+			fields[i] = ve.Field + ": " + ve.Message
+		}
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEvent, strings.Join(fields, "; "))
+	}
+	return e, nil
+}
+
+// resourceName returns the GORM table name of entity.
+func resourceName(entity core.Entity) string {
+	if t, ok := entity.(schema.Tabler); ok {
+		return t.TableName()
+	}
+	t := reflect.TypeOf(entity)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return schema.NamingStrategy{}.TableName(t.Name())
+}
@@ -0,0 +1,101 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	events "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/kafka"
+)
+
+type dataset struct {
+	core.CoreModel
+	Rows int `json:"rows"`
+}
+
+type report struct {
+	core.CoreModel
+}
+
+func (report) TableName() string { return "reports_v2" }
+
+func TestFromEntity(t *testing.T) {
+	fixed := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	ctx := clock.WithClock(core.WithSubject(context.Background(), "user@domain.com"), clock.NewFake(fixed))
+	rc := events.RequestContext{SessionID: ids.New[ids.Session](), RequestID: ids.New[ids.Request](), Source: "dataset-api"}
+
+	ds := &dataset{Rows: 3}
+	ds.Name = "orders"
+	ds.OwnerID = "owner-1"
+	ds.Create("user@domain.com", "grasp-labs", ids.New[ids.Tenant]())
+
+	ev, err := events.FromEntity(ctx, ds, events.ActionCreated, rc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs := ev.Validate(); len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %+v", errs)
+	}
+	if ev.EventType != "datasets.created" || ev.EventSource != "dataset-api" {
+		t.Fatalf("unexpected type/source: %s %s", ev.EventType, ev.EventSource)
+	}
+	if ev.TenantID != ds.TenantID || ev.SessionID != rc.SessionID || ev.RequestID != rc.RequestID {
+		t.Fatalf("unexpected ids: %+v", ev)
+	}
+	if ev.OwnerID == nil || *ev.OwnerID != "owner-1" {
+		t.Fatalf("expected owner_id, got %v", ev.OwnerID)
+	}
+	if want := ds.URN("datasets").String(); ev.AffectedEntityURI == nil || *ev.AffectedEntityURI != want {
+		t.Fatalf("expected affected entity %s, got %v", want, ev.AffectedEntityURI)
+	}
+	if ev.CreatedBy != "user@domain.com" || !ev.Timestamp.Equal(fixed) {
+		t.Fatalf("unexpected created_by/timestamp: %s %s", ev.CreatedBy, ev.Timestamp)
+	}
+	if ev.Payload.Data["name"] != "orders" || ev.Payload.Data["rows"] != float64(3) {
+		t.Fatalf("unexpected payload: %v", ev.Payload.Data)
+	}
+	if ev.Tags.Data["tenant_id"] != ds.TenantID.String() {
+		t.Fatalf("expected entity tags, got %v", ev.Tags.Data)
+	}
+
+	again, err := events.FromEntity(ctx, ds, events.ActionCreated, rc)
+	if err != nil || again.ID != ev.ID {
+		t.Fatalf("expected the same event ID for a retry, got %s and %s (%v)", ev.ID, again.ID, err)
+	}
+	deleted, err := events.FromEntity(ctx, ds, events.ActionDeleted, rc)
+	if err != nil || deleted.EventType != "datasets.deleted" || deleted.ID == ev.ID {
+		t.Fatalf("unexpected delete event: %+v (%v)", deleted, err)
+	}
+}
+
+func TestFromEntity_table_name(t *testing.T) {
+	r := &report{}
+	r.Create("user@domain.com", "grasp-labs", ids.New[ids.Tenant]())
+	rc := events.RequestContext{SessionID: ids.New[ids.Session](), RequestID: ids.New[ids.Request](), Actor: "svc@domain.com", Source: "report-api"}
+
+	ev, err := events.FromEntity(context.Background(), r, events.ActionUpdated, rc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ev.EventType != "reports_v2.updated" || ev.CreatedBy != "svc@domain.com" {
+		t.Fatalf("unexpected event: %s by %s", ev.EventType, ev.CreatedBy)
+	}
+}
+
+func TestFromEntity_invalid(t *testing.T) {
+	ds := &dataset{}
+	ds.Create("user@domain.com", "grasp-labs", ids.New[ids.Tenant]())
+
+	_, err := events.FromEntity(context.Background(), ds, events.ActionCreated, events.RequestContext{Source: "dataset-api"})
+	if !errors.Is(err, events.ErrInvalidEvent) {
+		t.Fatalf("expected ErrInvalidEvent, got %v", err)
+	}
+	_, err = events.FromEntity(context.Background(), ds, "", events.RequestContext{})
+	if !errors.Is(err, events.ErrInvalidEvent) {
+		t.Fatalf("expected ErrInvalidEvent for an empty action, got %v", err)
+	}
+}
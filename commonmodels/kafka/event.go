@@ -1,19 +1,20 @@
 package event
 
 import (
-	"crypto/md5"
-	"encoding/hex"
-	"encoding/json"
+	"context"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
-	"github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/types"
-	verr "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validation_error"
-	"github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validators/email"
-	"github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validators/uri"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+	verr "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validators/email"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validators/uri"
 )
 
 // ValidationErrors is a collection of field-level validation errors.
@@ -23,19 +24,25 @@ type ValidationErrors []verr.ValidationError
 // MD5 hash validation: Ensure it’s 32 hex chars.
 var md5Re = regexp.MustCompile(`^[a-fA-F0-9]{32}$`)
 
+// hexRe matches hex digests of any length.
+var hexRe = regexp.MustCompile(`^[a-fA-F0-9]+$`)
+
+// EventNamespace is the UUIDv5 namespace of derived event IDs.
+var EventNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("urn:grasp:event"))
+
 // Event define rigidly the data requirement of sending messages to
 // DS Event Stream platform
 type Event struct {
-	ID                uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	SessionID         uuid.UUID `gorm:"type:uuid" json:"session_id"`
-	RequestID         uuid.UUID `gorm:"type:uuid" json:"request_id"`
-	TenantID          uuid.UUID `gorm:"type:uuid" json:"tenant_id"`
-	OwnerID           *string   `json:"owner_id,omitempty"`
-	EventType         string    `json:"event_type"`
-	EventSource       string    `json:"event_source"`
-	EventSourceURI    *string   `json:"event_source_uri,omitempty"`
-	AffectedEntityURI *string   `json:"affected_entity_uri,omitempty"`
-	Message           *string   `json:"message,omitempty"`
+	ID                uuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
+	SessionID         ids.SessionID `gorm:"type:uuid" json:"session_id"`
+	RequestID         ids.RequestID `gorm:"type:uuid" json:"request_id"`
+	TenantID          ids.TenantID  `gorm:"type:uuid" json:"tenant_id"`
+	OwnerID           *string       `json:"owner_id,omitempty"`
+	EventType         string        `json:"event_type"`
+	EventSource       string        `json:"event_source"`
+	EventSourceURI    *string       `json:"event_source_uri,omitempty"`
+	AffectedEntityURI *string       `json:"affected_entity_uri,omitempty"`
+	Message           *string       `json:"message,omitempty"`
 
 	//  Body has to be JSON - The Domain referenced object
 	Payload    *types.JSONB[map[string]any] `gorm:"type:jsonb" json:"payload,omitempty"`
@@ -45,29 +52,39 @@ type Event struct {
 	Tags      types.JSONB[map[string]string] `gorm:"type:jsonb" json:"tags,omitempty"`
 	Timestamp time.Time                      `json:"timestamp"`
 	CreatedBy string                         `json:"created_by"`
-	MD5Hash   string                         `json:"md5_hash"`
+
+	// PayloadHash is the hex digest of the canonical Payload JSON with
+	// HashAlgorithm; see HashPayload. MD5Hash holds the MD5 digest for
+	// consumers predating PayloadHash and is only set with HashMD5.
+	HashAlgorithm HashAlgorithm `json:"hash_algorithm,omitempty"`
+	PayloadHash   string        `json:"payload_hash,omitempty"`
+	MD5Hash       string        `json:"md5_hash,omitempty"`
 
 	// Context has to be json - Typically a bearer of processing information for consumers
 	Context    *types.JSONB[map[string]any] `gorm:"type:jsonb" json:"context,omitempty"`
 	ContextURI *string                      `json:"context_uri,omitempty"`
+	// ContextHash is the digest of the canonical Context JSON with
+	// HashAlgorithm, set when a ClaimCheck offloads Context.
+	ContextHash string `json:"context_hash,omitempty"`
 }
 
-// HashPayloadMD5 computes an MD5 hash of the event's Payload
-// and sets e.MD5Hash to the hex-encoded result.
-// Returns an error if JSON marshalling fails.
-func (e *Event) HashPayloadMD5() error {
-	if e.Payload == nil {
-		e.MD5Hash = ""
-		return nil
+// Stamp sets a zero Timestamp to the time of the clock in ctx (see
+// clock.WithClock).
+func (e *Event) Stamp(ctx context.Context) {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = clock.Now(ctx)
 	}
-	// Convert JSONB wrapper to raw JSON bytes
-	b, err := json.Marshal(e.Payload)
-	if err != nil {
-		return err
+}
+
+// DeriveID sets ID to a UUIDv5 derived from the tenant, request, event type,
+// affected entity and payload hash, so that a retried producer emits the
+// same ID and consumers can drop duplicates. Call it after HashPayload.
+func (e *Event) DeriveID() {
+	var affected string
+	if e.AffectedEntityURI != nil {
+		affected = *e.AffectedEntityURI
 	}
-	sum := md5.Sum(b)
-	e.MD5Hash = hex.EncodeToString(sum[:])
-	return nil
+	e.ID = ids.DeriveUUID(EventNamespace, e.TenantID.String(), e.RequestID.String(), e.EventType, affected, e.digest())
 }
 
 // Validate checks required fields, status values, and JSONB shape.
@@ -83,13 +100,13 @@ func (e *Event) Validate() ValidationErrors {
 	if e.ID == uuid.Nil {
 		req("id", "required")
 	}
-	if e.SessionID == uuid.Nil {
+	if e.SessionID.IsZero() {
 		req("session_id", "required")
 	}
-	if e.RequestID == uuid.Nil { // remove if RequestID is optional
+	if e.RequestID.IsZero() { // remove if RequestID is optional
 		req("request_id", "required")
 	}
-	if e.TenantID == uuid.Nil {
+	if e.TenantID.IsZero() {
 		req("tenant_id", "required")
 	}
 	if e.EventType == "" {
@@ -108,8 +125,8 @@ func (e *Event) Validate() ValidationErrors {
 	}
 	payloadURINilOrEmpty := e.PayloadURI == nil || strings.TrimSpace(*e.PayloadURI) == ""
 	if payloadEmpty && payloadURINilOrEmpty {
-		req("body", "body and body_uri cannot both be empty")
-		req("body_uri", "body and body_uri cannot both be empty")
+		req("payload", "payload and payload_uri cannot both be empty")
+		req("payload_uri", "payload and payload_uri cannot both be empty")
 	}
 
 	if ve := uri.ValidateURI("event_source_uri", e.EventSourceURI, false); ve != nil {
@@ -118,10 +135,19 @@ func (e *Event) Validate() ValidationErrors {
 	if ve := uri.ValidateURI("affected_entity_uri", e.AffectedEntityURI, false); ve != nil {
 		errs = append(errs, *ve)
 	}
-	if ve := uri.ValidateURI("body_uri", e.PayloadURI, false); ve != nil {
+	// Hashed payload and context URIs may reference claim-check blobs,
+	// which FileStore keeps as file:// URIs.
+	validatePayloadURI, validateContextURI := uri.ValidateURI, uri.ValidateURI
+	if e.PayloadHash != "" {
+		validatePayloadURI = uri.ValidateBlobURI
+	}
+	if e.ContextHash != "" {
+		validateContextURI = uri.ValidateBlobURI
+	}
+	if ve := validatePayloadURI("payload_uri", e.PayloadURI, false); ve != nil {
 		errs = append(errs, *ve)
 	}
-	if ve := uri.ValidateURI("context_uri", e.ContextURI, false); ve != nil {
+	if ve := validateContextURI("context_uri", e.ContextURI, false); ve != nil {
 		errs = append(errs, *ve)
 	}
 	if e.Timestamp.IsZero() {
@@ -136,12 +162,28 @@ func (e *Event) Validate() ValidationErrors {
 		req("owner_id", "cannot be empty when provided")
 	}
 
-	if e.MD5Hash == "" || !md5Re.MatchString(e.MD5Hash) {
+	hashErrs := len(errs)
+	if e.PayloadHash != "" {
+		if e.HashAlgorithm == "" {
+			req("hash_algorithm", "required")
+		} else if !e.HashAlgorithm.Valid() {
+			req("hash_algorithm", fmt.Sprintf("unsupported hash algorithm %q", e.HashAlgorithm))
+		} else if n := e.HashAlgorithm.hexLen(); len(e.PayloadHash) != n || !hexRe.MatchString(e.PayloadHash) {
+			req("payload_hash", fmt.Sprintf("must be a %d-char hex %s", n, e.HashAlgorithm))
+		}
+	} else if e.HashAlgorithm != "" && e.HashAlgorithm != HashMD5 {
+		req("payload_hash", "required")
+	}
+	if (e.PayloadHash == "" || e.MD5Hash != "") && !md5Re.MatchString(e.MD5Hash) {
 		req("md5_hash", "must be a 32-char hex MD5")
 	}
 	if e.Payload != nil {
 		if err := e.Payload.Validate(); err != nil {
-			req("body", "invalid JSON structure")
+			req("payload", "invalid JSON structure")
+		} else if len(errs) == hashErrs {
+			if field, _ := e.verifyPayloadHash(); field != "" {
+				req(field, "does not match payload")
+			}
 		}
 	}
 	if err := e.Tags.Validate(); err != nil {
@@ -153,7 +195,11 @@ func (e *Event) Validate() ValidationErrors {
 
 	if e.Context != nil {
 		if err := e.Context.Validate(); err != nil {
-			req("body", "invalid JSON structure")
+			req("context", "invalid JSON structure")
+		} else if e.ContextHash != "" && e.HashAlgorithm.Valid() {
+			if sum, err := hashJSON(e.HashAlgorithm, e.Context, false); err == nil && !strings.EqualFold(sum, e.ContextHash) {
+				req("context_hash", "does not match context")
+			}
 		}
 	}
 
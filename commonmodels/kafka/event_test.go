@@ -2,25 +2,36 @@
 package event_test
 
 import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 
-	events "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/kafka"
-	"github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/types"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	events "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/kafka"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
 )
 
 func strp(s string) *string { return &s }
-func validMD5() string      { return "d41d8cd98f00b204e9800998ecf8427e" } // 32 hex
+func validMD5() string      { return "44244ce1a15ee6d4dc270001564cb759" } // MD5 of {"k":"v"}
+func validSHA256() string {
+	return "666c1aa02e8068c6d5cc1d3295009432c16790bec28ec8ce119d0d1a18d61319" // SHA-256 of {"k":"v"}
+}
 
 func newValidEvent() events.Event {
 	return events.Event{
 		ID:                uuid.New(),
-		SessionID:         uuid.New(),
-		RequestID:         uuid.New(),
-		TenantID:          uuid.New(),
+		SessionID:         ids.New[ids.Session](),
+		RequestID:         ids.New[ids.Request](),
+		TenantID:          ids.New[ids.Tenant](),
 		EventType:         "created",
 		EventSource:       "unit-test",
 		EventSourceURI:    strp("https://example.com/source"),
@@ -69,8 +80,8 @@ func TestEventValidate_MissingRequireds(t *testing.T) {
 		{"timestamp", "required"},
 		{"created_by", "required"},
 		{"md5_hash", "32-char hex"},
-		{"body", "cannot both be empty"},
-		{"body_uri", "cannot both be empty"},
+		{"payload", "cannot both be empty"},
+		{"payload_uri", "cannot both be empty"},
 	}
 	for _, m := range must {
 		if !hasErr(errs, m.field, m.part) {
@@ -85,15 +96,15 @@ func TestEventValidate_BodyVsBodyURI(t *testing.T) {
 	ev.Payload = nil
 	ev.PayloadURI = nil
 	errs := ev.Validate()
-	if !hasErr(errs, "body", "cannot both be empty") || !hasErr(errs, "body_uri", "cannot both be empty") {
-		t.Fatalf("expected both body/body_uri emptiness errors, got: %+v", errs)
+	if !hasErr(errs, "payload", "cannot both be empty") || !hasErr(errs, "payload_uri", "cannot both be empty") {
+		t.Fatalf("expected both payload/payload_uri emptiness errors, got: %+v", errs)
 	}
 
 	// Only Body present (non-empty) -> OK
 	ev = newValidEvent()
 	ev.PayloadURI = nil
 	if errs := ev.Validate(); len(errs) != 0 {
-		t.Fatalf("body-only should be valid, got: %+v", errs)
+		t.Fatalf("payload-only should be valid, got: %+v", errs)
 	}
 
 	// Only BodyURI present (non-empty) -> OK
@@ -101,7 +112,7 @@ func TestEventValidate_BodyVsBodyURI(t *testing.T) {
 	ev.Payload = nil
 	ev.PayloadURI = strp("https://example.com/payload")
 	if errs := ev.Validate(); len(errs) != 0 {
-		t.Fatalf("body_uri-only should be valid, got: %+v", errs)
+		t.Fatalf("payload_uri-only should be valid, got: %+v", errs)
 	}
 }
 
@@ -113,7 +124,7 @@ func TestEventValidate_InvalidURIs(t *testing.T) {
 	ev.PayloadURI = &bad
 
 	errs := ev.Validate()
-	for _, f := range []string{"event_source_uri", "affected_entity_uri", "body_uri"} {
+	for _, f := range []string{"event_source_uri", "affected_entity_uri", "payload_uri"} {
 		if !hasErr(errs, f, "invalid URI") {
 			t.Errorf("expected %s invalid URI error, got: %+v", f, errs)
 		}
@@ -144,8 +155,15 @@ func TestEventValidate_JSONBStructureErrors(t *testing.T) {
 	ev.PayloadURI = strp("https://example.com/payload")
 
 	errs := ev.Validate()
-	if !hasErr(errs, "body", "invalid JSON structure") {
-		t.Errorf("expected body invalid JSON structure, got: %+v", errs)
+	if !hasErr(errs, "payload", "invalid JSON structure") {
+		t.Errorf("expected payload invalid JSON structure, got: %+v", errs)
+	}
+
+	ev = newValidEvent()
+	ev.Context = &types.JSONB[map[string]any]{Data: map[string]any{"bad": ch}}
+	errs = ev.Validate()
+	if !hasErr(errs, "context", "invalid JSON structure") || hasErr(errs, "payload", "invalid JSON structure") {
+		t.Errorf("expected only context invalid JSON structure, got: %+v", errs)
 	}
 }
 
@@ -158,3 +176,130 @@ func TestEventValidate_OwnerIDProvidedButEmpty(t *testing.T) {
 		t.Errorf("expected owner_id empty error, got: %+v", errs)
 	}
 }
+
+func TestEvent_DeriveID(t *testing.T) {
+	a := newValidEvent()
+	a.DeriveID()
+	b := a
+	b.ID = uuid.Nil
+	b.Timestamp = a.Timestamp.Add(time.Second)
+	b.DeriveID()
+	if a.ID != b.ID || a.ID.Version() != 5 {
+		t.Fatalf("expected the same v5 ID for a retried event, got %s and %s", a.ID, b.ID)
+	}
+	b.MD5Hash = "00000000000000000000000000000000"
+	b.DeriveID()
+	if a.ID == b.ID {
+		t.Fatalf("expected a different ID for a different payload")
+	}
+}
+
+func TestEvent_HashPayload(t *testing.T) {
+	ev := newValidEvent()
+	if err := ev.HashPayload(""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ev.HashAlgorithm != events.HashSHA256 || ev.PayloadHash != validSHA256() || ev.MD5Hash != "" {
+		t.Fatalf("expected a SHA-256 hash only, got %q %q %q", ev.HashAlgorithm, ev.PayloadHash, ev.MD5Hash)
+	}
+	if errs := ev.Validate(); len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %+v", errs)
+	}
+
+	if err := ev.HashPayload(events.HashMD5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ev.PayloadHash != validMD5() || ev.MD5Hash != validMD5() {
+		t.Fatalf("expected the MD5 hash in both fields, got %q %q", ev.PayloadHash, ev.MD5Hash)
+	}
+	if errs := ev.Validate(); len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %+v", errs)
+	}
+
+	if err := ev.HashPayload("crc32"); !errors.Is(err, events.ErrUnsupportedHash) {
+		t.Fatalf("expected ErrUnsupportedHash, got %v", err)
+	}
+}
+
+func TestEvent_HashPayload_canonical(t *testing.T) {
+	// Key order, number spelling and HTML characters do not change the hash.
+	a := newValidEvent()
+	a.Payload = &types.JSONB[map[string]any]{Data: map[string]any{"b": float64(10), "a": "<x> & y"}}
+	var b events.Event
+	if err := json.Unmarshal([]byte(`{"payload": {"a": "\u003cx\u003e & y", "b": 1.0E1}}`), &b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.HashPayload(events.HashSHA256); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.HashPayload(events.HashSHA256); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sum := sha256.Sum256([]byte(`{"a":"<x> & y","b":10}`))
+	if a.PayloadHash != hex.EncodeToString(sum[:]) || b.PayloadHash != a.PayloadHash {
+		t.Fatalf("expected the hash of the canonical JSON, got %s and %s", a.PayloadHash, b.PayloadHash)
+	}
+}
+
+func TestEventValidate_HashMismatch(t *testing.T) {
+	ev := newValidEvent()
+	if err := ev.HashPayload(events.HashSHA256); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ev.Payload.Data["k"] = "changed"
+	if errs := ev.Validate(); !hasErr(errs, "payload_hash", "does not match payload") {
+		t.Fatalf("expected a payload_hash mismatch, got: %+v", errs)
+	}
+	if err := ev.VerifyPayloadHash(); !errors.Is(err, events.ErrHashMismatch) {
+		t.Fatalf("expected ErrHashMismatch, got %v", err)
+	}
+
+	ev = newValidEvent()
+	ev.MD5Hash = "00000000000000000000000000000000"
+	if errs := ev.Validate(); !hasErr(errs, "md5_hash", "does not match payload") {
+		t.Fatalf("expected an md5_hash mismatch, got: %+v", errs)
+	}
+
+	ev = newValidEvent()
+	ev.HashAlgorithm, ev.PayloadHash = "crc32", "abcd"
+	if errs := ev.Validate(); !hasErr(errs, "hash_algorithm", "unsupported") {
+		t.Fatalf("expected an unsupported algorithm error, got: %+v", errs)
+	}
+	ev.HashAlgorithm = events.HashSHA256
+	if errs := ev.Validate(); !hasErr(errs, "payload_hash", "64-char hex sha256") {
+		t.Fatalf("expected a payload_hash format error, got: %+v", errs)
+	}
+
+	ev = newValidEvent()
+	ev.HashAlgorithm, ev.ContextHash = events.HashSHA256, validSHA256()
+	ev.Context.Data["k"] = "changed"
+	if errs := ev.Validate(); !hasErr(errs, "context_hash", "does not match context") {
+		t.Fatalf("expected a context_hash mismatch, got: %+v", errs)
+	}
+}
+
+func TestEventValidate_LegacyMD5(t *testing.T) {
+	// Events hashed before canonicalization carry the MD5 of the
+	// encoding/json output, which escapes HTML characters.
+	ev := newValidEvent()
+	ev.Payload = &types.JSONB[map[string]any]{Data: map[string]any{"html": "<b>"}}
+	b, _ := json.Marshal(ev.Payload)
+	sum := md5.Sum(b)
+	ev.MD5Hash = hex.EncodeToString(sum[:])
+	if errs := ev.Validate(); len(errs) != 0 {
+		t.Fatalf("expected a legacy MD5 to pass, got: %+v", errs)
+	}
+	ev.HashAlgorithm = events.HashMD5
+	if errs := ev.Validate(); !hasErr(errs, "md5_hash", "does not match payload") {
+		t.Fatalf("expected canonical MD5 only with an algorithm set, got: %+v", errs)
+	}
+}
+
+func TestEvent_Stamp(t *testing.T) {
+	fixed := time.Date(2025, 7, 9, 14, 30, 0, 0, time.UTC)
+	var e events.Event
+	e.Stamp(clock.WithClock(context.Background(), clock.NewFake(fixed)))
+	if !e.Timestamp.Equal(fixed) {
+		t.Fatalf("expected timestamp %s, got %s", fixed, e.Timestamp)
+	}
+}
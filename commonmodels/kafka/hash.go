@@ -0,0 +1,168 @@
+package event
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/jcs"
+)
+
+// HashAlgorithm names the digest in Event.PayloadHash.
+type HashAlgorithm string
+
+const (
+	// HashMD5 is kept for consumers that only read md5_hash.
+	HashMD5 HashAlgorithm = "md5"
+	// HashSHA256 is the default.
+	HashSHA256 HashAlgorithm = "sha256"
+)
+
+// DefaultHashAlgorithm is used by HashPayload when no algorithm is given.
+var DefaultHashAlgorithm = HashSHA256
+
+var (
+	// ErrUnsupportedHash is returned (wrapped) for unknown hash algorithms.
+	ErrUnsupportedHash = errors.New("unsupported hash algorithm")
+	// ErrHashMismatch is returned (wrapped) when the payload hash of an
+	// event does not match its payload.
+	ErrHashMismatch = errors.New("payload hash does not match payload")
+)
+
+var hashers = map[HashAlgorithm]func() hash.Hash{
+	HashMD5:    md5.New,
+	HashSHA256: sha256.New,
+}
+
+// Valid reports whether a is a supported algorithm.
+func (a HashAlgorithm) Valid() bool {
+	_, ok := hashers[a]
+	return ok
+}
+
+// Sum returns the hex-encoded digest of b.
+func (a HashAlgorithm) Sum(b []byte) (string, error) {
+	newHash, ok := hashers[a]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedHash, a)
+	}
+	h := newHash()
+	h.Write(b)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hexLen is the length of a hex-encoded digest of a.
+func (a HashAlgorithm) hexLen() int {
+	if newHash, ok := hashers[a]; ok {
+		return 2 * newHash().Size()
+	}
+	return 0
+}
+
+// HashPayload hashes the RFC 8785 canonical JSON of Payload with alg, or
+// DefaultHashAlgorithm when alg is empty, and records the algorithm and
+// digest in HashAlgorithm and PayloadHash. With MD5 the digest is also set in
+// MD5Hash; otherwise MD5Hash is cleared. Without a payload the hashes are
+// cleared.
+func (e *Event) HashPayload(alg HashAlgorithm) error {
+	if alg == "" {
+		alg = DefaultHashAlgorithm
+	}
+	if !alg.Valid() {
+		return fmt.Errorf("%w: %q", ErrUnsupportedHash, alg)
+	}
+	e.HashAlgorithm, e.PayloadHash, e.MD5Hash = alg, "", ""
+	if e.Payload == nil {
+		return nil
+	}
+	sum, err := hashJSON(alg, e.Payload, false)
+	if err != nil {
+		return err
+	}
+	e.PayloadHash = sum
+	if alg == HashMD5 {
+		e.MD5Hash = sum
+	}
+	return nil
+}
+
+// HashPayloadMD5 hashes Payload with MD5.
+//
+// Deprecated: use HashPayload, which defaults to SHA-256.
+func (e *Event) HashPayloadMD5() error {
+	return e.HashPayload(HashMD5)
+}
+
+// VerifyPayloadHash checks PayloadHash, and MD5Hash when set, against
+// Payload. Events without a payload pass. MD5Hash of events without a
+// HashAlgorithm may also be the MD5 of the encoding/json output, which is
+// what HashPayloadMD5 produced before payloads were canonicalized.
+func (e *Event) VerifyPayloadHash() error {
+	field, err := e.verifyPayloadHash()
+	if field != "" {
+		return fmt.Errorf("%w: %s", ErrHashMismatch, field)
+	}
+	return err
+}
+
+// verifyPayloadHash returns the JSON name of the mismatching hash field, or
+// an error when the payload cannot be hashed.
+func (e *Event) verifyPayloadHash() (string, error) {
+	if e.Payload == nil {
+		return "", nil
+	}
+	if e.PayloadHash != "" {
+		sum, err := hashJSON(e.HashAlgorithm, e.Payload, false)
+		if err != nil {
+			return "", err
+		}
+		if !strings.EqualFold(sum, e.PayloadHash) {
+			return "payload_hash", nil
+		}
+	}
+	if e.MD5Hash != "" {
+		sum, err := hashJSON(HashMD5, e.Payload, false)
+		if err != nil {
+			return "", err
+		}
+		if strings.EqualFold(sum, e.MD5Hash) {
+			return "", nil
+		}
+		if e.HashAlgorithm == "" {
+			if legacy, err := hashJSON(HashMD5, e.Payload, true); err == nil && strings.EqualFold(legacy, e.MD5Hash) {
+				return "", nil
+			}
+		}
+		return "md5_hash", nil
+	}
+	return "", nil
+}
+
+// digest returns the payload hash of e, preferring PayloadHash.
+func (e *Event) digest() string {
+	if e.PayloadHash != "" {
+		return e.PayloadHash
+	}
+	return e.MD5Hash
+}
+
+// hashJSON hashes the canonical JSON of v, or its encoding/json output when
+// legacy is set.
+func hashJSON(alg HashAlgorithm, v any, legacy bool) (string, error) {
+	var b []byte
+	var err error
+	if legacy {
+		b, err = json.Marshal(v)
+	} else {
+		b, err = jcs.Marshal(v)
+	}
+	if err != nil {
+		return "", err
+	}
+	return alg.Sum(b)
+}
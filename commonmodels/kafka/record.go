@@ -0,0 +1,109 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Header keys set by Event.Record so consumers can route without decoding
+// the value.
+const (
+	HeaderEventID       = "event_id"
+	HeaderEventType     = "event_type"
+	HeaderEventSource   = "event_source"
+	HeaderTenantID      = "tenant_id"
+	HeaderRequestID     = "request_id"
+	HeaderSessionID     = "session_id"
+	HeaderHashAlgorithm = "hash_algorithm"
+	HeaderPayloadHash   = "payload_hash"
+	HeaderMD5Hash       = "md5_hash"
+)
+
+var (
+	// ErrInvalidRecord is returned (wrapped) for records whose value is not
+	// an Event.
+	ErrInvalidRecord = errors.New("invalid record")
+	// ErrHeaderMismatch is returned (wrapped) when a routing header differs
+	// from the event in the value.
+	ErrHeaderMismatch = errors.New("record header does not match event")
+)
+
+// Record is a client-agnostic Kafka record. Copy its fields to and from the
+// message type of the Kafka client in use.
+type Record struct {
+	Key     []byte
+	Headers []Header
+	Value   []byte
+}
+
+// Header returns the value of the first header with the given key.
+func (r Record) Header(key string) (string, bool) {
+	return headerValue(r.Headers, key)
+}
+
+// Record encodes e as a Kafka record: the event JSON as value and its
+// routing fields as headers (see the Header constants). Empty fields have no
+// header.
+//
+// The key is the AffectedEntityURI, or the tenant ID without one, so that
+// the events of an entity stay ordered within a partition.
+func (e *Event) Record() (Record, error) {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return Record{}, err
+	}
+	var r Record
+	for _, h := range e.routingHeaders() {
+		if h.value != "" {
+			r.Headers = append(r.Headers, Header{Key: h.key, Value: []byte(h.value)})
+		}
+	}
+	if e.AffectedEntityURI != nil && *e.AffectedEntityURI != "" {
+		r.Key = []byte(*e.AffectedEntityURI)
+	} else if !e.TenantID.IsZero() {
+		r.Key = []byte(e.TenantID.String())
+	}
+	r.Value = value
+	return r, nil
+}
+
+// EventFromRecord decodes the event in r.Value and checks that every
+// routing header present agrees with it. Other headers are ignored.
+func EventFromRecord(r Record) (*Event, error) {
+	var e Event
+	if err := json.Unmarshal(r.Value, &e); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+	for _, h := range e.routingHeaders() {
+		if v, ok := r.Header(h.key); ok && v != h.value {
+			return nil, fmt.Errorf("%w: %s is %q, event has %q", ErrHeaderMismatch, h.key, v, h.value)
+		}
+	}
+	return &e, nil
+}
+
+type routingHeader struct {
+	key, value string
+}
+
+// routingHeaders returns the header values of e; zero IDs are empty.
+func (e *Event) routingHeaders() []routingHeader {
+	eventID := ""
+	if e.ID != uuid.Nil {
+		eventID = e.ID.String()
+	}
+	return []routingHeader{
+		{HeaderEventID, eventID},
+		{HeaderEventType, e.EventType},
+		{HeaderEventSource, e.EventSource},
+		{HeaderTenantID, idString(e.TenantID)},
+		{HeaderRequestID, idString(e.RequestID)},
+		{HeaderSessionID, idString(e.SessionID)},
+		{HeaderHashAlgorithm, string(e.HashAlgorithm)},
+		{HeaderPayloadHash, e.PayloadHash},
+		{HeaderMD5Hash, e.MD5Hash},
+	}
+}
@@ -0,0 +1,91 @@
+package event_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	events "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/kafka"
+)
+
+func TestRecord_roundtrip(t *testing.T) {
+	e := fullEvent()
+	r, err := e.Record()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(r.Key) != *e.AffectedEntityURI {
+		t.Fatalf("expected the affected entity as key, got %s", r.Key)
+	}
+	want := map[string]string{
+		events.HeaderEventID:       e.ID.String(),
+		events.HeaderEventType:     e.EventType,
+		events.HeaderEventSource:   e.EventSource,
+		events.HeaderTenantID:      e.TenantID.String(),
+		events.HeaderRequestID:     e.RequestID.String(),
+		events.HeaderSessionID:     e.SessionID.String(),
+		events.HeaderHashAlgorithm: string(e.HashAlgorithm),
+		events.HeaderPayloadHash:   e.PayloadHash,
+		events.HeaderMD5Hash:       e.MD5Hash,
+	}
+	if len(r.Headers) != len(want) {
+		t.Fatalf("expected %d headers, got %+v", len(want), r.Headers)
+	}
+	for k, v := range want {
+		if got, ok := r.Header(k); !ok || got != v {
+			t.Fatalf("header %s: expected %q, got %q", k, v, got)
+		}
+	}
+
+	back, err := events.EventFromRecord(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(e, back) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", back, e)
+	}
+}
+
+func TestRecord_minimal(t *testing.T) {
+	e := minimalEvent()
+	e.TenantID = fullEvent().TenantID
+	r, err := e.Record()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(r.Key) != e.TenantID.String() {
+		t.Fatalf("expected the tenant as key, got %s", r.Key)
+	}
+	if _, ok := r.Header(events.HeaderRequestID); ok {
+		t.Fatalf("expected no header for a zero request id")
+	}
+	if _, err := events.EventFromRecord(r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRecord_header_mismatch(t *testing.T) {
+	r, err := fullEvent().Record()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, h := range r.Headers {
+		if h.Key == events.HeaderTenantID {
+			r.Headers[i].Value = []byte(minimalEvent().ID.String())
+		}
+	}
+	if _, err := events.EventFromRecord(r); !errors.Is(err, events.ErrHeaderMismatch) {
+		t.Fatalf("expected ErrHeaderMismatch, got %v", err)
+	}
+
+	// A header the event does not have is a mismatch too.
+	r, _ = minimalEvent().Record()
+	r.Headers = append(r.Headers, events.Header{Key: events.HeaderTenantID, Value: []byte("x")}, events.Header{Key: "trace", Value: []byte("abc")})
+	if _, err := events.EventFromRecord(r); !errors.Is(err, events.ErrHeaderMismatch) {
+		t.Fatalf("expected ErrHeaderMismatch, got %v", err)
+	}
+
+	if _, err := events.EventFromRecord(events.Record{Value: []byte("{")}); !errors.Is(err, events.ErrInvalidRecord) {
+		t.Fatalf("expected ErrInvalidRecord, got %v", err)
+	}
+}
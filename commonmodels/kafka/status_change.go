@@ -0,0 +1,54 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/urn"
+)
+
+// EventTypeStatusChanged is the EventType of events built from a
+// core.StatusChange.
+const EventTypeStatusChanged = "status_changed"
+
+// NewStatusChangeEvent builds an Event publishing a status history record.
+//
+// The change itself is the Payload, the actor becomes CreatedBy and, when
+// EntityType is set, AffectedEntityURI is the entity's URN. The payload hash
+// is computed; the caller still has to Validate the event. The event ID is
+// derived from the change ID, so publishing a change twice yields the same
+// event.
+func NewStatusChangeEvent(change core.StatusChange, sessionID ids.SessionID, requestID ids.RequestID, source string) (*Event, error) {
+	b, err := json.Marshal(change)
+	if err != nil {
+		return nil, err
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Sprintf("status changed from %s to %s", change.From, change.To)
+	e := &Event{
+		ID:          ids.DeriveUUID(EventNamespace, change.ID.String()),
+		SessionID:   sessionID,
+		RequestID:   requestID,
+		TenantID:    change.TenantID,
+		EventType:   EventTypeStatusChanged,
+		EventSource: source,
+		Message:     &msg,
+		Payload:     &types.JSONB[map[string]any]{Data: payload},
+		Timestamp:   change.ChangedAt,
+		CreatedBy:   change.Actor,
+	}
+	if ref := urn.New(change.TenantID, change.EntityType, change.EntityID.String()); ref.Validate() == nil {
+		e.AffectedEntityURI = ref.Ptr()
+	}
+	if err := e.HashPayload(DefaultHashAlgorithm); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
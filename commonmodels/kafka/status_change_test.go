@@ -0,0 +1,50 @@
+package event_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	events "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/kafka"
+)
+
+func TestNewStatusChangeEvent(t *testing.T) {
+	change := core.StatusChange{
+		ID:         uuid.New(),
+		TenantID:   ids.New[ids.Tenant](),
+		EntityID:   ids.New[ids.Entity](),
+		EntityType: "datasets",
+		From:       status.Active,
+		To:         status.Suspended,
+		Actor:      "admin@example.com",
+		ReasonCode: "abuse",
+		Reason:     "spam reports",
+		ChangedAt:  time.Now().UTC(),
+	}
+
+	ev, err := events.NewStatusChangeEvent(change, ids.New[ids.Session](), ids.New[ids.Request](), "unit-test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs := ev.Validate(); len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %+v", errs)
+	}
+	if ev.EventType != events.EventTypeStatusChanged || ev.TenantID != change.TenantID {
+		t.Fatalf("unexpected event: %+v", ev)
+	}
+	if got := ev.Payload.Data["reason_code"]; got != "abuse" {
+		t.Fatalf("expected reason_code in payload, got %v", got)
+	}
+	again, err := events.NewStatusChangeEvent(change, ids.New[ids.Session](), ids.New[ids.Request](), "unit-test")
+	if err != nil || again.ID != ev.ID {
+		t.Fatalf("expected the same event ID for the same change, got %s and %s", ev.ID, again.ID)
+	}
+	want := "urn:grasp:" + change.TenantID.String() + ":datasets:" + change.EntityID.String()
+	if ev.AffectedEntityURI == nil || *ev.AffectedEntityURI != want {
+		t.Fatalf("expected affected entity %s, got %v", want, ev.AffectedEntityURI)
+	}
+}
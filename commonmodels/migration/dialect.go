@@ -0,0 +1,291 @@
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm/schema"
+
+	sqldialects "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/sql_dialects"
+)
+
+// dialect renders DDL statements for one database.
+type dialect struct {
+	name  sqldialects.DatabaseDialect
+	quote func(string) string
+	// types maps generic column kinds to the dialect's column types. The
+	// optional "key_string" kind is used for unbounded strings that are
+	// indexed, where "string" cannot be.
+	types map[string]string
+	// extensions maps column types to the statement installing them.
+	extensions map[string]string
+	// indexMethods maps column types to the index access method used for
+	// single column indexes on them.
+	indexMethods map[string]string
+}
+
+var dialects = map[sqldialects.DatabaseDialect]dialect{
+	sqldialects.DialectPostgres: {
+		name:  sqldialects.DialectPostgres,
+		quote: func(s string) string { return `"` + strings.ReplaceAll(s, `"`, `""`) + `"` },
+		types: map[string]string{
+			"uuid":   "uuid",
+			"json":   "jsonb",
+			"ltree":  "ltree",
+			"bool":   "boolean",
+			"int8":   "smallint",
+			"int16":  "smallint",
+			"int32":  "integer",
+			"int64":  "bigint",
+			"float":  "double precision",
+			"string": "text",
+			"time":   "timestamptz",
+			"bytes":  "bytea",
+		},
+		extensions:   map[string]string{"ltree": "CREATE EXTENSION IF NOT EXISTS ltree"},
+		indexMethods: map[string]string{"ltree": "gist"},
+	},
+	sqldialects.DialectMySQL: {
+		name:  sqldialects.DialectMySQL,
+		quote: func(s string) string { return "`" + strings.ReplaceAll(s, "`", "``") + "`" },
+		types: map[string]string{
+			"uuid":       "char(36)",
+			"json":       "json",
+			"ltree":      "varchar(2048)",
+			"bool":       "boolean",
+			"int8":       "tinyint",
+			"int16":      "smallint",
+			"int32":      "int",
+			"int64":      "bigint",
+			"float":      "double",
+			"string":     "longtext",
+			"key_string": "varchar(255)",
+			"time":       "datetime(3)",
+			"bytes":      "longblob",
+		},
+	},
+	sqldialects.DialectSQLite: {
+		name:  sqldialects.DialectSQLite,
+		quote: func(s string) string { return `"` + strings.ReplaceAll(s, `"`, `""`) + `"` },
+		types: map[string]string{
+			"uuid":   "text",
+			"json":   "text",
+			"ltree":  "text",
+			"bool":   "numeric",
+			"int8":   "integer",
+			"int16":  "integer",
+			"int32":  "integer",
+			"int64":  "integer",
+			"float":  "real",
+			"string": "text",
+			"time":   "datetime",
+			"bytes":  "blob",
+		},
+	},
+}
+
+func dialectFor(name sqldialects.DatabaseDialect) (dialect, error) {
+	d, ok := dialects[name]
+	if !ok {
+		return dialect{}, fmt.Errorf("%w: %q", ErrUnsupportedDialect, name)
+	}
+	return d, nil
+}
+
+// columnType maps a GORM field to the dialect's column type. JSON columns
+// (type:jsonb / types.JSONB) become jsonb, json or text depending on the
+// dialect, and core.LTree paths ltree on Postgres.
+func (d dialect) columnType(f *schema.Field) string {
+	kind := strings.ToLower(f.TagSettings["TYPE"])
+	if kind == "" {
+		kind = string(f.DataType)
+	}
+	switch kind {
+	case "jsonb", "json":
+		return d.types["json"]
+	case "uuid", "ltree":
+		return d.types[kind]
+	}
+	switch f.DataType {
+	case schema.Bool:
+		return d.types["bool"]
+	case schema.Int, schema.Uint:
+		switch {
+		case f.Size <= 8:
+			return d.types["int8"]
+		case f.Size <= 16:
+			return d.types["int16"]
+		case f.Size <= 32:
+			return d.types["int32"]
+		default:
+			return d.types["int64"]
+		}
+	case schema.Float:
+		return d.types["float"]
+	case schema.String:
+		if f.Size > 0 && d.name != sqldialects.DialectSQLite {
+			return fmt.Sprintf("varchar(%d)", f.Size)
+		}
+		if t, ok := d.types["key_string"]; ok && indexed(f) {
+			return t
+		}
+		return d.types["string"]
+	case schema.Time:
+		return d.types["time"]
+	case schema.Bytes:
+		return d.types["bytes"]
+	}
+	// Explicit column types (e.g. type:citext) are used verbatim.
+	return kind
+}
+
+// indexed reports whether f is part of a key or index.
+func indexed(f *schema.Field) bool {
+	if f.PrimaryKey {
+		return true
+	}
+	for _, k := range []string{"INDEX", "UNIQUEINDEX", "UNIQUE"} {
+		if _, ok := f.TagSettings[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (d dialect) columnDef(c Column) string {
+	def := d.quote(c.Name) + " " + c.Type
+	if c.NotNull {
+		def += " NOT NULL"
+	}
+	if c.Default != "" {
+		def += " DEFAULT " + c.Default
+	}
+	return def
+}
+
+func (d dialect) createTable(t Table) []string {
+	var defs, pk []string
+	for _, c := range t.Columns {
+		defs = append(defs, "  "+d.columnDef(c))
+		if c.PrimaryKey {
+			pk = append(pk, d.quote(c.Name))
+		}
+	}
+	if len(pk) > 0 {
+		defs = append(defs, "  PRIMARY KEY ("+strings.Join(pk, ", ")+")")
+	}
+	stmts := []string{"CREATE TABLE " + d.quote(t.Name) + " (\n" + strings.Join(defs, ",\n") + "\n)"}
+	for _, idx := range t.Indexes {
+		stmts = append(stmts, d.createIndex(t.Name, idx))
+	}
+	return stmts
+}
+
+func (d dialect) dropTable(t Table) []string {
+	return []string{"DROP TABLE " + d.quote(t.Name)}
+}
+
+func (d dialect) createIndex(table string, idx Index) string {
+	cols := make([]string, len(idx.Columns))
+	for i, c := range idx.Columns {
+		cols[i] = d.quote(c)
+	}
+	kind := "INDEX"
+	if idx.Unique {
+		kind = "UNIQUE INDEX"
+	}
+	using := ""
+	if idx.Method != "" {
+		using = "USING " + idx.Method + " "
+	}
+	return fmt.Sprintf("CREATE %s %s ON %s %s(%s)", kind, d.quote(idx.Name), d.quote(table), using, strings.Join(cols, ", "))
+}
+
+// indexMethod returns the access method for idx on t, e.g. gist for ltree
+// paths on Postgres so the <@ operator of core.SubtreeOf can use the index.
+func (d dialect) indexMethod(t Table, idx Index) string {
+	if len(idx.Columns) != 1 {
+		return ""
+	}
+	for _, c := range t.Columns {
+		if c.Name == idx.Columns[0] {
+			return d.indexMethods[c.Type]
+		}
+	}
+	return ""
+}
+
+// missingExtensions returns the statements installing the extensions used by
+// columns of next but by none of prev.
+func (d dialect) missingExtensions(prev, next Snapshot) []string {
+	used := func(s Snapshot) map[string]bool {
+		m := map[string]bool{}
+		for _, t := range s.Tables {
+			for _, c := range t.Columns {
+				if _, ok := d.extensions[c.Type]; ok {
+					m[c.Type] = true
+				}
+			}
+		}
+		return m
+	}
+	before, after := used(prev), used(next)
+	var stmts []string
+	for typ := range after {
+		if !before[typ] {
+			stmts = append(stmts, d.extensions[typ])
+		}
+	}
+	sort.Strings(stmts)
+	return stmts
+}
+
+func (d dialect) dropIndex(table string, idx Index) string {
+	if d.name == sqldialects.DialectMySQL {
+		return fmt.Sprintf("DROP INDEX %s ON %s", d.quote(idx.Name), d.quote(table))
+	}
+	return "DROP INDEX " + d.quote(idx.Name)
+}
+
+func (d dialect) addColumn(table string, c Column) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", d.quote(table), d.columnDef(c))
+}
+
+func (d dialect) dropColumn(table string, c Column) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", d.quote(table), d.quote(c.Name))
+}
+
+// alterColumn changes column from into to.
+func (d dialect) alterColumn(table string, from, to Column) ([]string, error) {
+	if from.PrimaryKey != to.PrimaryKey {
+		return nil, fmt.Errorf("%w: primary key of %s.%s", ErrUnsupportedChange, table, to.Name)
+	}
+	switch d.name {
+	case sqldialects.DialectMySQL:
+		return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s", d.quote(table), d.columnDef(to))}, nil
+	case sqldialects.DialectPostgres:
+		prefix := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s ", d.quote(table), d.quote(to.Name))
+		var stmts []string
+		if from.Type != to.Type {
+			stmts = append(stmts, fmt.Sprintf("%sTYPE %s USING %s::%s", prefix, to.Type, d.quote(to.Name), to.Type))
+		}
+		if from.NotNull != to.NotNull {
+			if to.NotNull {
+				stmts = append(stmts, prefix+"SET NOT NULL")
+			} else {
+				stmts = append(stmts, prefix+"DROP NOT NULL")
+			}
+		}
+		if from.Default != to.Default {
+			if to.Default != "" {
+				stmts = append(stmts, prefix+"SET DEFAULT "+to.Default)
+			} else {
+				stmts = append(stmts, prefix+"DROP DEFAULT")
+			}
+		}
+		return stmts, nil
+	default:
+		return nil, fmt.Errorf("%w: altering %s.%s on %s", ErrUnsupportedChange, table, to.Name, d.name)
+	}
+}
@@ -0,0 +1,301 @@
+// Package migration generates versioned up/down SQL migrations for entities
+// embedding core.CoreModel.
+//
+// Models are described through GORM's schema parser, so column names, types,
+// defaults and indexes follow the same gorm tags used at runtime. Each run
+// returns a Snapshot of the generated schema; store it next to the
+// migrations and pass it to the next run to only emit the difference:
+//
+//	g := migration.Generator{Dialect: sqldialects.DialectPostgres}
+//	m, snap, err := g.Diff(prev, "20250818120000", "add_datasets", &Dataset{})
+//	for name, sql := range m.Files() {
+//		os.WriteFile(filepath.Join(dir, name), []byte(sql), 0o644)
+//	}
+package migration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm/schema"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	sqldialects "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/sql_dialects"
+)
+
+var (
+	// ErrUnsupportedDialect is returned for dialects without a DDL mapping.
+	ErrUnsupportedDialect = errors.New("migration: unsupported dialect")
+	// ErrUnsupportedChange is returned for changes the dialect cannot
+	// express as ALTER statements (e.g. column type changes on SQLite).
+	ErrUnsupportedChange = errors.New("migration: unsupported change")
+	// ErrNotEntity is returned for models not embedding core.CoreModel.
+	ErrNotEntity = errors.New("migration: model does not embed core.CoreModel")
+)
+
+// Column describes a table column.
+type Column struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primary_key,omitempty"`
+	NotNull    bool   `json:"not_null,omitempty"`
+	Default    string `json:"default,omitempty"`
+}
+
+// Index describes a (possibly composite) table index.
+type Index struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique,omitempty"`
+	// Method is the index access method (e.g. gist); empty for the
+	// dialect's default.
+	Method string `json:"method,omitempty"`
+}
+
+// Table describes a table with its columns in declaration order.
+type Table struct {
+	Name    string   `json:"name"`
+	Columns []Column `json:"columns"`
+	Indexes []Index  `json:"indexes"`
+}
+
+// Snapshot is the schema produced by a migration run. It is JSON encoded so
+// it can be committed alongside the generated SQL.
+type Snapshot struct {
+	Dialect sqldialects.DatabaseDialect `json:"dialect"`
+	Version string                      `json:"version"`
+	Tables  []Table                     `json:"tables"`
+}
+
+// LoadSnapshot decodes a snapshot written with json.Marshal.
+func LoadSnapshot(b []byte) (Snapshot, error) {
+	var s Snapshot
+	err := json.Unmarshal(b, &s)
+	return s, err
+}
+
+// Migration is a versioned pair of up and down scripts.
+type Migration struct {
+	Version string
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Empty reports whether the migration has no statements.
+func (m Migration) Empty() bool {
+	return m.Up == "" && m.Down == ""
+}
+
+// Files returns the scripts keyed by file name, using the
+// <version>_<name>.up.sql / .down.sql convention.
+func (m Migration) Files() map[string]string {
+	base := m.Version + "_" + m.Name
+	return map[string]string{
+		base + ".up.sql":   m.Up,
+		base + ".down.sql": m.Down,
+	}
+}
+
+// Generator builds migrations for a single dialect.
+type Generator struct {
+	Dialect sqldialects.DatabaseDialect
+	// Naming resolves table, column and index names; nil uses GORM's
+	// default schema.NamingStrategy.
+	Naming schema.Namer
+}
+
+// Snapshot describes the given models without generating SQL.
+func (g Generator) Snapshot(version string, models ...any) (Snapshot, error) {
+	d, err := dialectFor(g.Dialect)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	naming := g.Naming
+	if naming == nil {
+		naming = schema.NamingStrategy{}
+	}
+	cache := &sync.Map{}
+	snap := Snapshot{Dialect: g.Dialect, Version: version}
+	for _, model := range models {
+		if _, ok := model.(core.Entity); !ok {
+			return Snapshot{}, fmt.Errorf("%w: %T", ErrNotEntity, model)
+		}
+		s, err := schema.Parse(model, cache, naming)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		snap.Tables = append(snap.Tables, describe(d, s))
+	}
+	sort.Slice(snap.Tables, func(i, j int) bool { return snap.Tables[i].Name < snap.Tables[j].Name })
+	return snap, nil
+}
+
+// Create generates a migration creating the tables and indexes of models.
+func (g Generator) Create(version, name string, models ...any) (Migration, Snapshot, error) {
+	return g.Diff(Snapshot{Dialect: g.Dialect}, version, name, models...)
+}
+
+// Diff generates a migration from prev to the current models. Tables, columns
+// and indexes missing in prev are created, those no longer present are
+// dropped, and changed columns are altered. The down script reverts the up
+// script in reverse order.
+func (g Generator) Diff(prev Snapshot, version, name string, models ...any) (Migration, Snapshot, error) {
+	next, err := g.Snapshot(version, models...)
+	if err != nil {
+		return Migration{}, Snapshot{}, err
+	}
+	if prev.Dialect != "" && prev.Dialect != g.Dialect {
+		return Migration{}, Snapshot{}, fmt.Errorf("migration: snapshot dialect %q does not match %q", prev.Dialect, g.Dialect)
+	}
+	d, _ := dialectFor(g.Dialect)
+
+	var up, down []string
+	add := func(u, dn []string) {
+		up = append(up, u...)
+		// Down statements run in reverse order of the up statements.
+		down = append(append([]string(nil), dn...), down...)
+	}
+
+	// Extensions are never dropped: other schemas may depend on them.
+	up = append(up, d.missingExtensions(prev, next)...)
+
+	old := tablesByName(prev.Tables)
+	for _, t := range next.Tables {
+		o, ok := old[t.Name]
+		if !ok {
+			add(d.createTable(t), d.dropTable(t))
+			continue
+		}
+		u, dn, err := diffTable(d, o, t)
+		if err != nil {
+			return Migration{}, Snapshot{}, err
+		}
+		add(u, dn)
+	}
+	current := tablesByName(next.Tables)
+	for _, t := range prev.Tables {
+		if _, ok := current[t.Name]; !ok {
+			add(d.dropTable(t), d.createTable(t))
+		}
+	}
+
+	m := Migration{Version: version, Name: name, Up: script(up), Down: script(down)}
+	return m, next, nil
+}
+
+func diffTable(d dialect, prev, next Table) (up, down []string, err error) {
+	// Indexes are dropped before and created after column changes.
+	oldIdx, newIdx := indexesByName(prev.Indexes), indexesByName(next.Indexes)
+	for _, idx := range prev.Indexes {
+		if n, ok := newIdx[idx.Name]; !ok || !reflect.DeepEqual(n, idx) {
+			up = append(up, d.dropIndex(prev.Name, idx))
+			down = append(down, d.createIndex(prev.Name, idx))
+		}
+	}
+
+	oldCols, newCols := columnsByName(prev.Columns), columnsByName(next.Columns)
+	for _, c := range next.Columns {
+		o, ok := oldCols[c.Name]
+		switch {
+		case !ok:
+			up = append(up, d.addColumn(next.Name, c))
+			down = append(down, d.dropColumn(next.Name, c))
+		case o != c:
+			u, err := d.alterColumn(next.Name, o, c)
+			if err != nil {
+				return nil, nil, err
+			}
+			dn, err := d.alterColumn(next.Name, c, o)
+			if err != nil {
+				return nil, nil, err
+			}
+			up = append(up, u...)
+			down = append(down, dn...)
+		}
+	}
+	for _, c := range prev.Columns {
+		if _, ok := newCols[c.Name]; !ok {
+			up = append(up, d.dropColumn(prev.Name, c))
+			down = append(down, d.addColumn(prev.Name, c))
+		}
+	}
+
+	for _, idx := range next.Indexes {
+		if o, ok := oldIdx[idx.Name]; !ok || !reflect.DeepEqual(o, idx) {
+			up = append(up, d.createIndex(next.Name, idx))
+			down = append(down, d.dropIndex(next.Name, idx))
+		}
+	}
+
+	// Undo in reverse order.
+	for i, j := 0, len(down)-1; i < j; i, j = i+1, j-1 {
+		down[i], down[j] = down[j], down[i]
+	}
+	return up, down, nil
+}
+
+func describe(d dialect, s *schema.Schema) Table {
+	t := Table{Name: s.Table}
+	for _, f := range s.Fields {
+		if f.DBName == "" || f.IgnoreMigration {
+			continue
+		}
+		c := Column{
+			Name:       f.DBName,
+			Type:       d.columnType(f),
+			PrimaryKey: f.PrimaryKey,
+			NotNull:    f.NotNull || f.PrimaryKey,
+		}
+		if f.HasDefaultValue && f.DefaultValue != "" {
+			c.Default = f.DefaultValue
+		}
+		t.Columns = append(t.Columns, c)
+	}
+	for _, idx := range s.ParseIndexes() {
+		i := Index{Name: idx.Name, Unique: idx.Class == "UNIQUE"}
+		for _, opt := range idx.Fields {
+			i.Columns = append(i.Columns, opt.DBName)
+		}
+		i.Method = d.indexMethod(t, i)
+		t.Indexes = append(t.Indexes, i)
+	}
+	sort.Slice(t.Indexes, func(i, j int) bool { return t.Indexes[i].Name < t.Indexes[j].Name })
+	return t
+}
+
+func script(stmts []string) string {
+	if len(stmts) == 0 {
+		return ""
+	}
+	return strings.Join(stmts, ";\n") + ";\n"
+}
+
+func tablesByName(tables []Table) map[string]Table {
+	m := make(map[string]Table, len(tables))
+	for _, t := range tables {
+		m[t.Name] = t
+	}
+	return m
+}
+
+func columnsByName(cols []Column) map[string]Column {
+	m := make(map[string]Column, len(cols))
+	for _, c := range cols {
+		m[c.Name] = c
+	}
+	return m
+}
+
+func indexesByName(idx []Index) map[string]Index {
+	m := make(map[string]Index, len(idx))
+	for _, i := range idx {
+		m[i.Name] = i
+	}
+	return m
+}
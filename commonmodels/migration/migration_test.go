@@ -0,0 +1,148 @@
+package migration_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	sqldialects "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/sql_dialects"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/migration"
+)
+
+type Dataset struct {
+	core.CoreModel
+	FieldX string `json:"field_x"`
+}
+
+// DatasetV2 is Dataset after a schema change, mapped to the same table.
+type DatasetV2 struct {
+	core.CoreModel
+	Rows  int64  `gorm:"not null;default:0"`
+	Owner string `gorm:"size:64;uniqueIndex"`
+}
+
+func (DatasetV2) TableName() string { return "datasets" }
+
+func TestCreate_postgres(t *testing.T) {
+	g := migration.Generator{Dialect: sqldialects.DialectPostgres}
+	m, snap, err := g.Create("20250818120000", "create_datasets", &Dataset{})
+	assert.NoError(t, err)
+
+	assert.Contains(t, m.Up, `CREATE TABLE "datasets" (`)
+	assert.Contains(t, m.Up, `"id" uuid NOT NULL`)
+	assert.Contains(t, m.Up, `"metadata" jsonb`)
+	assert.Contains(t, m.Up, `"revision" bigint NOT NULL DEFAULT 1`)
+	assert.Contains(t, m.Up, `PRIMARY KEY ("id")`)
+	assert.Contains(t, m.Up, `CREATE INDEX "idx_datasets_tenant_id" ON "datasets" ("tenant_id");`)
+	assert.Contains(t, m.Up, `CREATE INDEX "idx_datasets_status_tenant" ON "datasets" ("status", "tenant_id");`)
+	assert.Equal(t, "DROP TABLE \"datasets\";\n", m.Down)
+
+	assert.Equal(t, map[string]string{
+		"20250818120000_create_datasets.up.sql":   m.Up,
+		"20250818120000_create_datasets.down.sql": m.Down,
+	}, m.Files())
+	assert.Equal(t, "20250818120000", snap.Version)
+	assert.Equal(t, "datasets", snap.Tables[0].Name)
+}
+
+func TestCreate_json_column_per_dialect(t *testing.T) {
+	for dialect, want := range map[sqldialects.DatabaseDialect]string{
+		sqldialects.DialectMySQL:  "`tags` json",
+		sqldialects.DialectSQLite: `"tags" text`,
+	} {
+		m, _, err := migration.Generator{Dialect: dialect}.Create("1", "init", &Dataset{})
+		assert.NoError(t, err)
+		assert.Contains(t, m.Up, want)
+	}
+
+	_, _, err := migration.Generator{Dialect: sqldialects.DialectOracle}.Create("1", "init", &Dataset{})
+	assert.True(t, errors.Is(err, migration.ErrUnsupportedDialect))
+
+	_, _, err = migration.Generator{Dialect: sqldialects.DialectPostgres}.Create("1", "init", &struct{ ID int }{})
+	assert.True(t, errors.Is(err, migration.ErrNotEntity))
+}
+
+func TestDiff_against_snapshot(t *testing.T) {
+	g := migration.Generator{Dialect: sqldialects.DialectPostgres}
+	_, snap, err := g.Create("1", "init", &Dataset{})
+	assert.NoError(t, err)
+
+	// Snapshots survive a JSON round-trip.
+	b, err := json.Marshal(snap)
+	assert.NoError(t, err)
+	prev, err := migration.LoadSnapshot(b)
+	assert.NoError(t, err)
+
+	m, _, err := g.Diff(prev, "2", "rows_and_owner", &DatasetV2{})
+	assert.NoError(t, err)
+	assert.Equal(t, `ALTER TABLE "datasets" ADD COLUMN "rows" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "datasets" ADD COLUMN "owner" varchar(64);
+ALTER TABLE "datasets" DROP COLUMN "field_x";
+CREATE UNIQUE INDEX "idx_datasets_owner" ON "datasets" ("owner");
+`, m.Up)
+	assert.Equal(t, `DROP INDEX "idx_datasets_owner";
+ALTER TABLE "datasets" ADD COLUMN "field_x" text;
+ALTER TABLE "datasets" DROP COLUMN "owner";
+ALTER TABLE "datasets" DROP COLUMN "rows";
+`, m.Down)
+
+	// No changes, no statements.
+	m, _, err = g.Diff(prev, "3", "noop", &Dataset{})
+	assert.NoError(t, err)
+	assert.True(t, m.Empty())
+}
+
+func TestDiff_alter_column(t *testing.T) {
+	prev := migration.Snapshot{Dialect: sqldialects.DialectMySQL, Tables: []migration.Table{{
+		Name:    "datasets",
+		Columns: []migration.Column{{Name: "field_x", Type: "varchar(10)"}},
+	}}}
+	m, _, err := migration.Generator{Dialect: sqldialects.DialectMySQL}.Diff(prev, "2", "widen", &Dataset{})
+	assert.NoError(t, err)
+	assert.Contains(t, m.Up, "ALTER TABLE `datasets` MODIFY COLUMN `field_x` longtext;")
+	assert.Contains(t, m.Down, "ALTER TABLE `datasets` MODIFY COLUMN `field_x` varchar(10);")
+
+	prev.Dialect = sqldialects.DialectSQLite
+	_, _, err = migration.Generator{Dialect: sqldialects.DialectSQLite}.Diff(prev, "2", "widen", &Dataset{})
+	assert.True(t, errors.Is(err, migration.ErrUnsupportedChange))
+}
+
+type Folder struct {
+	core.CoreModel
+	core.Hierarchy
+}
+
+func TestCreate_hierarchy_path_column(t *testing.T) {
+	g := migration.Generator{Dialect: sqldialects.DialectPostgres}
+	m, snap, err := g.Create("1", "init", &Folder{})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(m.Up, "CREATE EXTENSION IF NOT EXISTS ltree;\n"))
+	assert.Contains(t, m.Up, `"path" ltree`)
+	assert.Contains(t, m.Up, `CREATE INDEX "idx_folders_path" ON "folders" USING gist ("path");`)
+	assert.Contains(t, m.Up, `CREATE INDEX "idx_folders_parent_id" ON "folders" ("parent_id");`)
+	assert.NotContains(t, m.Down, "EXTENSION")
+
+	// The extension is only installed once.
+	m, _, err = g.Diff(snap, "2", "noop", &Folder{})
+	assert.NoError(t, err)
+	assert.True(t, m.Empty())
+
+	m, _, err = migration.Generator{Dialect: sqldialects.DialectSQLite}.Create("1", "init", &Folder{})
+	assert.NoError(t, err)
+	assert.Contains(t, m.Up, `"path" text`)
+	assert.Contains(t, m.Up, `CREATE INDEX "idx_folders_path" ON "folders" ("path");`)
+	assert.NotContains(t, m.Up, "EXTENSION")
+}
+
+func TestCreate_mysql_strings(t *testing.T) {
+	m, _, err := migration.Generator{Dialect: sqldialects.DialectMySQL}.Create("1", "init", &DatasetV2{})
+	assert.NoError(t, err)
+	assert.Contains(t, m.Up, "`description` longtext")
+	// Indexed strings stay keyable.
+	assert.Contains(t, m.Up, "`status` varchar(255)")
+	assert.Contains(t, m.Up, "`owner` varchar(64)")
+}
@@ -0,0 +1,133 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrNoLocale is returned by LocalizedText.Validate when no locale has a
+	// non-empty text.
+	ErrNoLocale = errors.New("localized text requires at least one locale")
+	// ErrInvalidLocale is returned for keys that are not locale tags.
+	ErrInvalidLocale = errors.New("invalid locale")
+)
+
+// DefaultLocale is the last resort when resolving a LocalizedText.
+var DefaultLocale = "en"
+
+// LocaleFallbacks lists, per locale, the locales tried when it has no text.
+// DefaultLocale is always tried last.
+var LocaleFallbacks = map[string][]string{
+	"nb": {"no"},
+	"no": {"nb"},
+	"nn": {"nb", "no"},
+}
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// LocalizedText is a text keyed by locale, e.g. {"en": "Orders", "nb":
+// "Ordrer"}, persisted as JSONB.
+type LocalizedText map[string]string
+
+// Resolve returns the text for locale, trying in order the locale itself,
+// its base language ("nb-NO" → "nb"), LocaleFallbacks and DefaultLocale. The
+// boolean is false when none of these has a text.
+func (t LocalizedText) Resolve(locale string) (string, bool) {
+	for _, l := range resolutionOrder(locale) {
+		if s := t[l]; s != "" {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// Render returns the value to serialize for a requested locale: the whole
+// map when locale is "" or "*", otherwise the resolved string.
+//
+//	resp["name"] = ds.LocalizedName.Render(c.QueryParam("locale"))
+func (t LocalizedText) Render(locale string) any {
+	if locale == "" || locale == "*" {
+		return map[string]string(t)
+	}
+	s, _ := t.Resolve(locale)
+	return s
+}
+
+// Validate checks that every key is a locale tag and that at least one
+// locale has a non-empty text.
+func (t LocalizedText) Validate() error {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	found := false
+	for _, k := range keys {
+		if !localePattern.MatchString(k) {
+			return fmt.Errorf("%w: %q", ErrInvalidLocale, k)
+		}
+		if strings.TrimSpace(t[k]) != "" {
+			found = true
+		}
+	}
+	if !found {
+		return ErrNoLocale
+	}
+	return nil
+}
+
+// GormDataType makes GORM map this to a JSONB column.
+func (LocalizedText) GormDataType() string {
+	return "jsonb"
+}
+
+// Value is called by database/sql to turn the map into JSON bytes.
+func (t LocalizedText) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	return json.Marshal(map[string]string(t))
+}
+
+// Scan is called by database/sql to populate the map from JSON bytes.
+func (t *LocalizedText) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*map[string]string)(t))
+	case string:
+		return json.Unmarshal([]byte(v), (*map[string]string)(t))
+	default:
+		return fmt.Errorf("LocalizedText.Scan: Unsupported type %T", v)
+	}
+}
+
+func resolutionOrder(locale string) []string {
+	var order []string
+	add := func(l string) {
+		for _, o := range order {
+			if o == l {
+				return
+			}
+		}
+		order = append(order, l)
+	}
+	if locale != "" {
+		add(locale)
+		base := strings.ToLower(strings.SplitN(locale, "-", 2)[0])
+		add(base)
+		for _, f := range LocaleFallbacks[base] {
+			add(f)
+		}
+	}
+	add(DefaultLocale)
+	return order
+}
@@ -0,0 +1,139 @@
+// Package urn defines the URN format used to reference entities across
+// services:
+//
+//	urn:grasp:<tenant>:<resource>:<id>
+//
+// e.g. urn:grasp:25948ccc-3a2e-4f4f-9f5e-6f4b4f8f2a11:dataset:0c1f...
+//
+// The tenant is a UUID, the resource a lowercase type name and the id the
+// entity's identifier within the tenant.
+package urn
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+)
+
+// Namespace is the URN namespace identifier (NID) of our URNs.
+const Namespace = "grasp"
+
+const prefix = "urn:" + Namespace + ":"
+
+// ErrInvalidURN is returned (wrapped) for malformed URNs.
+var ErrInvalidURN = errors.New("invalid urn")
+
+var (
+	resourcePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	idPattern       = regexp.MustCompile(`^[A-Za-z0-9._~-]+$`)
+	// rfc8141 matches any syntactically valid URN ("urn:<nid>:<nss>").
+	rfc8141 = regexp.MustCompile(`^(?i:urn):[A-Za-z0-9][A-Za-z0-9-]{0,30}[A-Za-z0-9]:[A-Za-z0-9()+,\-.:=@;$_!*'%/?#~&]+$`)
+)
+
+// URN identifies an entity of a resource type within a tenant.
+type URN struct {
+	TenantID ids.TenantID
+	Resource string
+	ID       string
+}
+
+// New returns the URN of an entity.
+func New(tenantID ids.TenantID, resource, id string) URN {
+	return URN{TenantID: tenantID, Resource: resource, ID: id}
+}
+
+// Parse parses and validates s. The "urn" prefix and namespace are matched
+// case-insensitively as required by RFC 8141.
+func Parse(s string) (URN, error) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return URN{}, fmt.Errorf("%w: %q must start with %q", ErrInvalidURN, s, prefix)
+	}
+	parts := strings.SplitN(s[len(prefix):], ":", 3)
+	if len(parts) != 3 {
+		return URN{}, fmt.Errorf("%w: %q must have tenant, resource and id", ErrInvalidURN, s)
+	}
+	tenantID, err := ids.Parse[ids.Tenant](parts[0])
+	if err != nil {
+		return URN{}, fmt.Errorf("%w: tenant %q is not a uuid", ErrInvalidURN, parts[0])
+	}
+	u := URN{TenantID: tenantID, Resource: parts[1], ID: parts[2]}
+	if err := u.Validate(); err != nil {
+		return URN{}, err
+	}
+	return u, nil
+}
+
+// MustParse is like Parse but panics on error. Intended for constants in
+// tests and examples.
+func MustParse(s string) URN {
+	u, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// Validate reports whether all parts of u are set and well formed.
+func (u URN) Validate() error {
+	if u.TenantID.IsZero() {
+		return fmt.Errorf("%w: tenant is required", ErrInvalidURN)
+	}
+	if !resourcePattern.MatchString(u.Resource) {
+		return fmt.Errorf("%w: resource %q must match %s", ErrInvalidURN, u.Resource, resourcePattern)
+	}
+	if !idPattern.MatchString(u.ID) {
+		return fmt.Errorf("%w: id %q must match %s", ErrInvalidURN, u.ID, idPattern)
+	}
+	return nil
+}
+
+// String formats u as urn:grasp:<tenant>:<resource>:<id>.
+func (u URN) String() string {
+	return prefix + u.TenantID.String() + ":" + u.Resource + ":" + u.ID
+}
+
+// Ptr returns the formatted URN as a *string, e.g. for
+// Event.AffectedEntityURI.
+func (u URN) Ptr() *string {
+	s := u.String()
+	return &s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u URN) MarshalText() ([]byte, error) {
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *URN) UnmarshalText(b []byte) error {
+	parsed, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// IsURN reports whether s uses the "urn:" scheme.
+func IsURN(s string) bool {
+	return len(s) >= 4 && strings.EqualFold(s[:4], "urn:")
+}
+
+// ValidateAny validates s as a URN: ours are fully parsed, URNs of other
+// namespaces only checked against the RFC 8141 syntax.
+func ValidateAny(s string) error {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		_, err := Parse(s)
+		return err
+	}
+	if !rfc8141.MatchString(s) {
+		return fmt.Errorf("%w: %q", ErrInvalidURN, s)
+	}
+	return nil
+}
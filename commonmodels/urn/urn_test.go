@@ -0,0 +1,77 @@
+package urn_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/core"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/urn"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validators/uri"
+)
+
+var tenant = ids.MustParse[ids.Tenant]("25948ccc-3a2e-4f4f-9f5e-6f4b4f8f2a11")
+
+func TestURN_roundtrip(t *testing.T) {
+	u := urn.New(tenant, "dataset", "orders-2025")
+	s := u.String()
+	assert.Equal(t, "urn:grasp:25948ccc-3a2e-4f4f-9f5e-6f4b4f8f2a11:dataset:orders-2025", s)
+
+	parsed, err := urn.Parse(s)
+	assert.NoError(t, err)
+	assert.Equal(t, u, parsed)
+
+	// Prefix is case-insensitive.
+	parsed, err = urn.Parse("URN:Grasp:25948ccc-3a2e-4f4f-9f5e-6f4b4f8f2a11:dataset:orders-2025")
+	assert.NoError(t, err)
+	assert.Equal(t, u, parsed)
+
+	b, err := json.Marshal(map[string]urn.URN{"ref": u})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"ref":"`+s+`"}`, string(b))
+	var back map[string]urn.URN
+	assert.NoError(t, json.Unmarshal(b, &back))
+	assert.Equal(t, u, back["ref"])
+}
+
+func TestURN_invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"urn:other:25948ccc-3a2e-4f4f-9f5e-6f4b4f8f2a11:dataset:1",
+		"urn:grasp:not-a-uuid:dataset:1",
+		"urn:grasp:00000000-0000-0000-0000-000000000000:dataset:1",
+		"urn:grasp:25948ccc-3a2e-4f4f-9f5e-6f4b4f8f2a11:Dataset:1",
+		"urn:grasp:25948ccc-3a2e-4f4f-9f5e-6f4b4f8f2a11:dataset",
+		"urn:grasp:25948ccc-3a2e-4f4f-9f5e-6f4b4f8f2a11:dataset:a:b",
+	} {
+		_, err := urn.Parse(s)
+		assert.True(t, errors.Is(err, urn.ErrInvalidURN), s)
+	}
+}
+
+func TestCoreModel_URN(t *testing.T) {
+	var c core.CoreModel
+	c.Create("user@domain.com", "grasp-labs", tenant)
+
+	u := c.URN("dataset")
+	assert.NoError(t, u.Validate())
+	assert.Equal(t, tenant, u.TenantID)
+	assert.Equal(t, c.ID.String(), u.ID)
+}
+
+func TestValidateURI_accepts_urns(t *testing.T) {
+	ok := urn.New(tenant, "dataset", "1").String()
+	assert.Nil(t, uri.ValidateURI("affected_entity_uri", &ok, true))
+
+	other := "urn:isbn:0451450523"
+	assert.Nil(t, uri.ValidateURI("affected_entity_uri", &other, true))
+
+	bad := "urn:grasp:not-a-uuid:dataset:1"
+	assert.NotNil(t, uri.ValidateURI("affected_entity_uri", &bad, true))
+
+	noHost := "https://"
+	assert.NotNil(t, uri.ValidateURI("affected_entity_uri", &noHost, true))
+}
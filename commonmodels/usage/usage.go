@@ -1,19 +1,25 @@
 package usage
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
-	err "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/enum/errors"
-	"github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/enum/status"
-	val_err "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validation_error"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	err "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/status"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	val_err "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
 )
 
+// Namespace is the UUIDv5 namespace of derived usage entry IDs.
+var Namespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("urn:grasp:usage"))
+
 type UsageEntry struct {
 	ID             uuid.UUID           `json:"id"`
-	TenantID       uuid.UUID           `json:"tenant_id"`
+	TenantID       ids.TenantID        `json:"tenant_id"`
 	OwnerID        *string             `json:"owner_id"` // pointer to allow null
-	ProductID      uuid.UUID           `json:"product_id"`
+	ProductID      ids.ProductID       `json:"product_id"`
 	MemoryMB       int16               `json:"memory_mb"`
 	StartTimestamp time.Time           `json:"start_timestamp"`
 	EndTimestamp   time.Time           `json:"end_timestamp"`
@@ -25,6 +31,14 @@ type UsageEntry struct {
 	CreatedBy      string              `json:"created_by"`
 }
 
+// Stamp sets a zero CreatedAt to the time of the clock in ctx (see
+// clock.WithClock).
+func (u *UsageEntry) Stamp(ctx context.Context) {
+	if u.CreatedAt.IsZero() {
+		u.CreatedAt = clock.Now(ctx)
+	}
+}
+
 func (u *UsageEntry) Validate(locale string) []val_err.ValidationError {
 	var errors []val_err.ValidationError
 	if u.ID == uuid.Nil {
@@ -35,7 +49,7 @@ func (u *UsageEntry) Validate(locale string) []val_err.ValidationError {
 			Code:    err.Required,
 		})
 	}
-	if u.TenantID == uuid.Nil {
+	if u.TenantID.IsZero() {
 		errors = append(errors, val_err.ValidationError{
 			Field:   "tenant_id",
 			Message: err.HumanMessageLocale(locale, err.Required, "tenant_id"),
@@ -43,7 +57,7 @@ func (u *UsageEntry) Validate(locale string) []val_err.ValidationError {
 			Code:    err.Required,
 		})
 	}
-	if u.ProductID == uuid.Nil {
+	if u.ProductID.IsZero() {
 		errors = append(errors, val_err.ValidationError{
 			Field:   "product_id",
 			Message: err.HumanMessageLocale(locale, err.Required, "product_id"),
@@ -130,3 +144,14 @@ func (u *UsageEntry) Validate(locale string) []val_err.ValidationError {
 	}
 	return errors
 }
+
+// DeriveID sets ID to a UUIDv5 derived from the tenant, product, owner and
+// start of the measured period, so reporting the same period twice yields the
+// same entry.
+func (u *UsageEntry) DeriveID() {
+	var owner string
+	if u.OwnerID != nil {
+		owner = *u.OwnerID
+	}
+	u.ID = ids.DeriveUUID(Namespace, u.TenantID.String(), u.ProductID.String(), owner, u.StartTimestamp.UTC().Format(time.RFC3339Nano))
+}
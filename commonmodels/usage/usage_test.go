@@ -1,6 +1,7 @@
 package usage_test
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 	"time"
@@ -8,13 +9,15 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
-	models "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/usage"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/clock"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	models "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/usage"
 )
 
 func TestUsageEntry_JSON_MarshalBasic(t *testing.T) {
 	id := uuid.New()
-	tenantID := uuid.New()
-	productID := uuid.New()
+	tenantID := ids.New[ids.Tenant]()
+	productID := ids.New[ids.Product]()
 	ownerID := "fancy-owner-id"
 
 	entry := models.UsageEntry{
@@ -93,8 +96,8 @@ func TestFieldValidation(t *testing.T) {
 func TestUsageEntry_Validate_EndBeforeStart(t *testing.T) {
 	entry := models.UsageEntry{
 		ID:             uuid.New(),
-		TenantID:       uuid.New(),
-		ProductID:      uuid.New(),
+		TenantID:       ids.New[ids.Tenant](),
+		ProductID:      ids.New[ids.Product](),
 		MemoryMB:       128,
 		StartTimestamp: time.Date(2025, 7, 9, 14, 32, 0, 0, time.UTC),
 		EndTimestamp:   time.Date(2025, 7, 9, 14, 30, 0, 0, time.UTC), // Before start!
@@ -121,9 +124,9 @@ func TestUsageEntry_Validate_EndBeforeStart(t *testing.T) {
 func TestUsageEntry_JSON_WithNullOwnerID(t *testing.T) {
 	entry := models.UsageEntry{
 		ID:             uuid.New(),
-		TenantID:       uuid.New(),
+		TenantID:       ids.New[ids.Tenant](),
 		OwnerID:        nil,
-		ProductID:      uuid.New(),
+		ProductID:      ids.New[ids.Product](),
 		MemoryMB:       128,
 		StartTimestamp: time.Now().UTC(),
 		EndTimestamp:   time.Now().UTC().Add(time.Minute),
@@ -146,8 +149,8 @@ func TestUsageEntry_JSON_WithNullOwnerID(t *testing.T) {
 func TestUsageEntry_Validate_NegativeValues(t *testing.T) {
 	entry := models.UsageEntry{
 		ID:             uuid.New(),
-		TenantID:       uuid.New(),
-		ProductID:      uuid.New(),
+		TenantID:       ids.New[ids.Tenant](),
+		ProductID:      ids.New[ids.Product](),
 		MemoryMB:       -128,
 		StartTimestamp: time.Now(),
 		EndTimestamp:   time.Now().Add(time.Minute),
@@ -176,3 +179,31 @@ func TestUsageEntry_Validate_NegativeValues(t *testing.T) {
 	assert.True(t, memoryError, "Should have validation error for negative memory_mb")
 	assert.True(t, durationError, "Should have validation error for negative duration")
 }
+
+func TestUsageEntry_DeriveID(t *testing.T) {
+	start := time.Date(2025, 7, 9, 14, 30, 0, 0, time.UTC)
+	a := models.UsageEntry{TenantID: ids.New[ids.Tenant](), ProductID: ids.New[ids.Product](), StartTimestamp: start}
+	a.DeriveID()
+	assert.Equal(t, uuid.Version(5), a.ID.Version())
+
+	b := a
+	b.StartTimestamp = start.In(time.FixedZone("CEST", 2*60*60))
+	b.DeriveID()
+	assert.Equal(t, a.ID, b.ID)
+
+	b.StartTimestamp = start.Add(time.Minute)
+	b.DeriveID()
+	assert.NotEqual(t, a.ID, b.ID)
+}
+
+func TestUsageEntry_Stamp(t *testing.T) {
+	fixed := time.Date(2025, 7, 9, 14, 30, 0, 0, time.UTC)
+	ctx := clock.WithClock(context.Background(), clock.NewFake(fixed))
+
+	var entry models.UsageEntry
+	entry.Stamp(ctx)
+	assert.Equal(t, fixed, entry.CreatedAt)
+
+	entry.Stamp(clock.WithClock(ctx, clock.NewFake(fixed.Add(time.Hour))))
+	assert.Equal(t, fixed, entry.CreatedAt, "Stamp keeps an existing CreatedAt")
+}
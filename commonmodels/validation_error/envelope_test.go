@@ -5,7 +5,7 @@ import (
 	"errors"
 	"testing"
 
-	ve "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validation_error"
+	ve "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -3,7 +3,7 @@ package validation_error_test
 import (
 	"testing"
 
-	ve "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validation_error"
+	ve "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
 )
 
 // Test for validating Locations.
@@ -13,8 +13,8 @@ import (
 	"fmt"
 	"strings"
 
-	ecode "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/enum/errors"
-	verr "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validation_error"
+	ecode "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	verr "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
 	"github.com/xeipuuv/gojsonschema"
 )
 
@@ -2,17 +2,19 @@ package json_schema_test
 
 import (
 	"encoding/json"
+	"os"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 
-	ecode "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/enum/errors"
-	events "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/kafka"
-	"github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/types"
-	verr "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validation_error"
-	js "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validators/json_schema"
+	ecode "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/enum/errors"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/ids"
+	events "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/kafka"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/types"
+	verr "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
+	js "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validators/json_schema"
 )
 
 var EventJSONSchema = []byte(`
@@ -96,9 +98,9 @@ func validMD5() string      { return "d41d8cd98f00b204e9800998ecf8427e" } // 32
 func newValidEvent() events.Event {
 	return events.Event{
 		ID:                uuid.New(),
-		SessionID:         uuid.New(),
-		RequestID:         uuid.New(),
-		TenantID:          uuid.New(),
+		SessionID:         ids.New[ids.Session](),
+		RequestID:         ids.New[ids.Request](),
+		TenantID:          ids.New[ids.Tenant](),
 		OwnerID:           nil,
 		EventType:         "created",
 		EventSource:       "unit-test",
@@ -311,3 +313,68 @@ func TestEvent_JSONSchema_Failures(t *testing.T) {
 		}
 	})
 }
+
+// publishedEventSchema is the schema documented for consumers.
+func publishedEventSchema(t *testing.T) []byte {
+	t.Helper()
+	b, err := os.ReadFile("../../../docs/eventModelSchema.json")
+	if err != nil {
+		t.Fatalf("read schema: %v", err)
+	}
+	return b
+}
+
+func TestEvent_PublishedSchema_matches_Event(t *testing.T) {
+	schema := publishedEventSchema(t)
+
+	ev := newValidEvent()
+	ev.Context = &types.JSONB[map[string]any]{Data: map[string]any{"trace": "abc"}}
+	if errs := js.ValidateAgainstSchema(marshal(t, ev), schema); errs != nil {
+		t.Fatalf("expected published schema to accept a valid event, got:\n%v", errs)
+	}
+
+	ev.Payload = nil
+	ev.PayloadURI = nil
+	if errs := js.ValidateAgainstSchema(marshal(t, ev), schema); errs == nil {
+		t.Fatalf("expected published schema to reject an event without payload and payload_uri")
+	}
+}
+
+func TestEvent_PublishedSchema_default_hash(t *testing.T) {
+	schema := publishedEventSchema(t)
+
+	ev := newValidEvent()
+	ev.MD5Hash = ""
+	ev.Context = &types.JSONB[map[string]any]{Data: map[string]any{"trace": "abc"}}
+	if err := ev.HashPayload(""); err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	if errs := ev.Validate(); len(errs) > 0 {
+		t.Fatalf("event does not validate: %v", errs)
+	}
+	b := marshal(t, ev)
+	if strings.Contains(string(b), "md5_hash") {
+		t.Fatalf("expected no md5_hash with the default algorithm: %s", b)
+	}
+	if errs := js.ValidateAgainstSchema(b, schema); errs != nil {
+		t.Fatalf("expected published schema to accept a SHA-256 event, got:\n%v", errs)
+	}
+
+	// MD5 and legacy md5_hash-only events stay valid.
+	if err := ev.HashPayload(events.HashMD5); err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	if errs := js.ValidateAgainstSchema(marshal(t, ev), schema); errs != nil {
+		t.Fatalf("expected published schema to accept an MD5 event, got:\n%v", errs)
+	}
+	legacy := newValidEvent()
+	if errs := js.ValidateAgainstSchema(marshal(t, legacy), schema); errs != nil {
+		t.Fatalf("expected published schema to accept a legacy event, got:\n%v", errs)
+	}
+
+	// An event without any hash is rejected.
+	ev.HashAlgorithm, ev.PayloadHash, ev.MD5Hash = "", "", ""
+	if errs := js.ValidateAgainstSchema(marshal(t, ev), schema); errs == nil {
+		t.Fatalf("expected published schema to reject an event without hash")
+	}
+}
@@ -4,11 +4,26 @@ import (
 	"net/url"
 	"strings"
 
-	verr "github.com/grasp-labs/ds-go-commonmodels/v3/commonmodels/validation_error"
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/urn"
+	verr "github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validation_error"
 )
 
 // required=false means: empty/nil is allowed.
+//
+// URNs have no host and are validated with urn.ValidateAny instead, so
+// urn:grasp:<tenant>:<resource>:<id> references are accepted.
 func ValidateURI(field string, v *string, required bool) *verr.ValidationError {
+	return validate(field, v, required, false)
+}
+
+// ValidateBlobURI is ValidateURI that also accepts file:// URIs with an
+// absolute path, as written by kafka's FileStore. Use it only for fields
+// referencing claim-check blobs.
+func ValidateBlobURI(field string, v *string, required bool) *verr.ValidationError {
+	return validate(field, v, required, true)
+}
+
+func validate(field string, v *string, required, allowFile bool) *verr.ValidationError {
 	if v == nil || strings.TrimSpace(*v) == "" {
 		if required {
 			return &verr.ValidationError{Field: field, Message: "required"}
@@ -17,7 +32,19 @@ func ValidateURI(field string, v *string, required bool) *verr.ValidationError {
 	}
 
 	s := strings.TrimSpace(*v)
+	if urn.IsURN(s) {
+		if err := urn.ValidateAny(s); err != nil {
+			return &verr.ValidationError{Field: field, Message: "invalid URI"}
+		}
+		return nil
+	}
 	u, err := url.ParseRequestURI(s)
+	if err == nil && strings.EqualFold(u.Scheme, "file") {
+		if allowFile && u.Host == "" && strings.HasPrefix(u.Path, "/") {
+			return nil
+		}
+		return &verr.ValidationError{Field: field, Message: "invalid URI"}
+	}
 	if err != nil || u.Scheme == "" || u.Host == "" {
 		return &verr.ValidationError{Field: field, Message: "invalid URI"}
 	}
@@ -0,0 +1,82 @@
+package uri_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grasp-labs/ds-go-commonmodels/v4/commonmodels/validators/uri"
+)
+
+func TestValidateURI(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"https", "https://api.grasp-labs.com/datasets/1", true},
+		{"http with port", "http://localhost:8080/x?y=1", true},
+		{"grasp urn", "urn:grasp:25948ccc-3a2e-4f4f-9f5e-6f4b4f8f2a11:dataset:orders-2025", true},
+		{"grasp urn upper case prefix", "URN:Grasp:25948ccc-3a2e-4f4f-9f5e-6f4b4f8f2a11:dataset:1", true},
+		{"other namespace urn", "urn:isbn:0451450523", true},
+		{"surrounding spaces", "  https://grasp-labs.com  ", true},
+		{"no scheme", "grasp-labs.com/datasets", false},
+		{"no host", "https:///datasets", false},
+		{"relative path", "/datasets/1", false},
+		{"grasp urn bad tenant", "urn:grasp:not-a-uuid:dataset:1", false},
+		{"grasp urn missing id", "urn:grasp:25948ccc-3a2e-4f4f-9f5e-6f4b4f8f2a11:dataset", false},
+		{"urn without namespace", "urn:", false},
+		{"urn bad namespace", "urn:-bad:x", false},
+		{"file", "file:///var/lib/events/payload.json", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := tc.value
+			err := uri.ValidateURI("event_source_uri", &v, true)
+			if tc.valid {
+				assert.Nil(t, err)
+				return
+			}
+			if assert.NotNil(t, err) {
+				assert.Equal(t, "event_source_uri", err.Field)
+				assert.Equal(t, "invalid URI", err.Message)
+			}
+		})
+	}
+}
+
+func TestValidateURI_required(t *testing.T) {
+	empty := "  "
+	assert.Nil(t, uri.ValidateURI("affected_entity_uri", nil, false))
+	assert.Nil(t, uri.ValidateURI("affected_entity_uri", &empty, false))
+
+	err := uri.ValidateURI("affected_entity_uri", nil, true)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "required", err.Message)
+	}
+	err = uri.ValidateURI("affected_entity_uri", &empty, true)
+	assert.NotNil(t, err)
+}
+
+func TestValidateBlobURI(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"https", "https://blobs.grasp-labs.com/events/1/payload.json", true},
+		{"file", "file:///var/lib/events/payload.json", true},
+		{"file with host", "file://host/var/lib/events/payload.json", false},
+		{"file relative", "file:payload.json", false},
+		{"no scheme", "payload.json", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := tc.value
+			err := uri.ValidateBlobURI("payload_uri", &v, true)
+			if tc.valid {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
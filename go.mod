@@ -1,4 +1,4 @@
-module github.com/grasp-labs/ds-go-commonmodels/v3
+module github.com/grasp-labs/ds-go-commonmodels/v4
 
 go 1.25.0
 